@@ -0,0 +1,90 @@
+package ethereum
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeEthAPI is a minimal in-process eth namespace, enough for the transactor
+// to build, sign and broadcast transactions without a node.
+type fakeEthAPI struct {
+	mu          sync.Mutex
+	chainID     *big.Int
+	gasPrice    *big.Int
+	gasEstimate uint64
+	estimateErr error
+	gasLimit    uint64
+	nonces      map[common.Address]uint64
+	sent        []*types.Transaction
+}
+
+func newFakeEthAPI(chainID uint64) *fakeEthAPI {
+	return &fakeEthAPI{
+		chainID:     new(big.Int).SetUint64(chainID),
+		gasPrice:    big.NewInt(1_000_000_000),
+		gasEstimate: 21000,
+		gasLimit:    30_000_000,
+		nonces:      make(map[common.Address]uint64),
+	}
+}
+
+func (api *fakeEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.chainID)
+}
+
+func (api *fakeEthAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(api.gasPrice)
+}
+
+func (api *fakeEthAPI) EstimateGas(args map[string]interface{}) (hexutil.Uint64, error) {
+	if api.estimateErr != nil {
+		return 0, api.estimateErr
+	}
+	return hexutil.Uint64(api.gasEstimate), nil
+}
+
+func (api *fakeEthAPI) GetTransactionCount(address common.Address, block string) hexutil.Uint64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return hexutil.Uint64(api.nonces[address])
+}
+
+func (api *fakeEthAPI) GetBlockByNumber(number string, full bool) *types.Header {
+	return &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   api.gasLimit,
+		Difficulty: common.Big0,
+	}
+}
+
+func (api *fakeEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.sent = append(api.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (api *fakeEthAPI) sentTxs() []*types.Transaction {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]*types.Transaction(nil), api.sent...)
+}
+
+func newFakeClient(api *fakeEthAPI) *ethclient.Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		panic(err)
+	}
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
@@ -0,0 +1,250 @@
+package common
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed chains.json
+var defaultChainsJSON []byte
+
+// DefaultBlockDuration is used for chains that are not present in the registry.
+const DefaultBlockDuration = 12 * time.Second
+
+// L2Family identifies the rollup stack a chain is built on. Chains in the same
+// family share node quirks (nonce accounting, fee components, ...).
+type L2Family string
+
+const (
+	L2None     L2Family = ""
+	L2Optimism L2Family = "optimism"
+	L2Arbitrum L2Family = "arbitrum"
+	L2ZkSync   L2Family = "zksync"
+)
+
+// ExplorerStandardEIP3091 marks explorers that serve /tx/{hash}, /address/{address},
+// /block/{number} and /token/{address} paths as described by EIP-3091.
+const ExplorerStandardEIP3091 = "EIP3091"
+
+var (
+	ErrUnknownChain = errors.New("unknown chain")
+	ErrInvalidChain = errors.New("invalid chain config")
+	ErrNoExplorer   = errors.New("chain has no explorer")
+)
+
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// FeeConfig holds the fee rules of a chain that the node's fee oracle doesn't
+// reflect. Amounts are in wei.
+type FeeConfig struct {
+	// MinGasPrice is the gas price below which validators drop transactions (BSC).
+	MinGasPrice uint64 `json:"minGasPrice,omitempty"`
+	// MinPriorityFee is the priority fee floor enforced by validators (Polygon).
+	MinPriorityFee uint64 `json:"minPriorityFee,omitempty"`
+	// ZeroTip marks chains whose sequencer ignores the priority fee (Arbitrum, zkSync).
+	ZeroTip bool `json:"zeroTip,omitempty"`
+}
+
+type Explorer struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Standard string `json:"standard"`
+}
+
+// ChainConfig describes an EVM chain. The JSON layout follows chainlist.org
+// (https://github.com/ethereum-lists/chains) extended with the fields the
+// transactor needs to adapt its behaviour to the chain.
+type ChainConfig struct {
+	ChainID        ChainID        `json:"chainId"`
+	Name           string         `json:"name"`
+	Chain          string         `json:"chain"`
+	ShortName      string         `json:"shortName"`
+	RPC            []string       `json:"rpc"`
+	NativeCurrency NativeCurrency `json:"nativeCurrency"`
+	Explorers      []Explorer     `json:"explorers"`
+	Testnet        bool           `json:"testnet"`
+
+	BlockTimeMs   uint64    `json:"blockTimeMs"`
+	EIP1559       bool      `json:"eip1559"`
+	L2            L2Family  `json:"l2,omitempty"`
+	Confirmations uint64    `json:"confirmations"`
+	Fees          FeeConfig `json:"fees"`
+}
+
+func (c *ChainConfig) Validate() error {
+	if c.ChainID == ChainID(UnknownChainID) {
+		return errors.Wrap(ErrInvalidChain, "chainId is required")
+	}
+	if c.Name == "" {
+		return errors.Wrapf(ErrInvalidChain, "chain %d: name is required", c.ChainID)
+	}
+	if c.NativeCurrency.Symbol == "" {
+		return errors.Wrapf(ErrInvalidChain, "chain %d: native currency symbol is required", c.ChainID)
+	}
+	if c.Fees.ZeroTip && c.Fees.MinPriorityFee > 0 {
+		return errors.Wrapf(ErrInvalidChain, "chain %d: zeroTip conflicts with minPriorityFee", c.ChainID)
+	}
+	switch c.L2 {
+	case L2None, L2Optimism, L2Arbitrum, L2ZkSync:
+	default:
+		return errors.Wrapf(ErrInvalidChain, "chain %d: unknown l2 family %q", c.ChainID, c.L2)
+	}
+	return nil
+}
+
+// BlockDuration returns the average block time of the chain.
+func (c *ChainConfig) BlockDuration() time.Duration {
+	if c.BlockTimeMs == 0 {
+		return DefaultBlockDuration
+	}
+	return time.Duration(c.BlockTimeMs) * time.Millisecond
+}
+
+// IsOptimismStack reports whether the chain runs the OP stack, whose nodes return
+// the nonce of the last executed tx + 1 regardless of pending transactions.
+func (c *ChainConfig) IsOptimismStack() bool {
+	return c.L2 == L2Optimism
+}
+
+// ExplorerURL renders an EIP-3091 explorer link, kind being one of "tx",
+// "address", "block" or "token".
+func (c *ChainConfig) ExplorerURL(kind string, value string) (string, error) {
+	for _, e := range c.Explorers {
+		if e.Standard == ExplorerStandardEIP3091 && e.URL != "" {
+			return fmt.Sprintf("%s/%s/%s", strings.TrimRight(e.URL, "/"), kind, value), nil
+		}
+	}
+	return "", errors.Wrapf(ErrNoExplorer, "chain %d", c.ChainID)
+}
+
+func (c *ChainConfig) TxURL(hash string) (string, error) {
+	return c.ExplorerURL("tx", hash)
+}
+
+func (c *ChainConfig) AddressURL(address string) (string, error) {
+	return c.ExplorerURL("address", address)
+}
+
+// ChainRegistry holds the known chains. It is safe for concurrent use and can be
+// extended at runtime.
+type ChainRegistry struct {
+	mu     sync.RWMutex
+	chains map[ChainID]*ChainConfig
+}
+
+func NewChainRegistry() *ChainRegistry {
+	return &ChainRegistry{
+		chains: make(map[ChainID]*ChainConfig),
+	}
+}
+
+// LoadJSON registers every chain of a chainlist-style JSON array, replacing
+// already known chains with the same id.
+func (r *ChainRegistry) LoadJSON(data []byte) error {
+	var configs []ChainConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return errors.Wrap(err, "failed to decode chain list")
+	}
+
+	for i := range configs {
+		if err := r.Register(configs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ChainRegistry) Register(cfg ChainConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chains[cfg.ChainID] = &cfg
+	return nil
+}
+
+// Get returns a copy of the chain config so callers can't mutate the registry.
+func (r *ChainRegistry) Get(chainID ChainID) (*ChainConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg, ok := r.chains[chainID]
+	if !ok {
+		return nil, false
+	}
+	cp := *cfg
+	cp.RPC = append([]string(nil), cfg.RPC...)
+	cp.Explorers = append([]Explorer(nil), cfg.Explorers...)
+	return &cp, true
+}
+
+func (r *ChainRegistry) MustGet(chainID ChainID) *ChainConfig {
+	cfg, ok := r.Get(chainID)
+	if !ok {
+		panic(errors.Wrapf(ErrUnknownChain, "chain %d", chainID))
+	}
+	return cfg
+}
+
+// ChainIDs returns the registered chain ids in ascending order.
+func (r *ChainRegistry) ChainIDs() []ChainID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]ChainID, 0, len(r.chains))
+	for id := range r.chains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (r *ChainRegistry) filter(testnet bool) []ChainID {
+	var ids []ChainID
+	for _, id := range r.ChainIDs() {
+		if cfg, ok := r.Get(id); ok && cfg.Testnet == testnet {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *ChainRegistry) Mainnets() []ChainID {
+	return r.filter(false)
+}
+
+func (r *ChainRegistry) Testnets() []ChainID {
+	return r.filter(true)
+}
+
+// DefaultChainRegistry is loaded from the embedded chains.json.
+var DefaultChainRegistry = NewChainRegistry()
+
+func init() {
+	if err := DefaultChainRegistry.LoadJSON(defaultChainsJSON); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterChain adds or replaces a chain in the default registry.
+func RegisterChain(cfg ChainConfig) error {
+	return DefaultChainRegistry.Register(cfg)
+}
+
+// GetChain looks a chain up in the default registry.
+func GetChain(chainID ChainID) (*ChainConfig, bool) {
+	return DefaultChainRegistry.Get(chainID)
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultChainRegistry(t *testing.T) {
+	sepolia, ok := GetChain(ChainID(EthereumSepolia))
+	require.True(t, ok)
+	require.True(t, sepolia.Testnet)
+	require.Equal(t, 12*time.Second, ChainID(EthereumSepolia).AverageBlockDuration())
+
+	op := DefaultChainRegistry.MustGet(ChainID(OptimismMainnet))
+	require.True(t, op.IsOptimismStack())
+	require.True(t, ChainID(OptimismMainnet).IsMainnet())
+
+	bsc := DefaultChainRegistry.MustGet(ChainID(BinanceChainID))
+	require.False(t, bsc.EIP1559)
+	require.Equal(t, "BNB", bsc.NativeCurrency.Symbol)
+
+	url, err := DefaultChainRegistry.MustGet(ChainID(EthereumMainnet)).TxURL("0xabc")
+	require.NoError(t, err)
+	require.Equal(t, "https://etherscan.io/tx/0xabc", url)
+
+	require.Equal(t, DefaultBlockDuration, ChainID(999999).AverageBlockDuration())
+	require.False(t, ChainID(UnknownChainID).IsMainnet())
+}
+
+func TestChainRegistryRegister(t *testing.T) {
+	r := NewChainRegistry()
+	require.ErrorIs(t, r.Register(ChainConfig{ChainID: 7}), ErrInvalidChain)
+
+	err := r.LoadJSON([]byte(`[{"chainId":100,"name":"Gnosis","nativeCurrency":{"symbol":"XDAI","decimals":18},"blockTimeMs":5000,"eip1559":true}]`))
+	require.NoError(t, err)
+
+	cfg, ok := r.Get(100)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, cfg.BlockDuration())
+
+	cfg.Name = "mutated"
+	require.Equal(t, "Gnosis", r.MustGet(100).Name)
+
+	_, err = cfg.TxURL("0x1")
+	require.ErrorIs(t, err, ErrNoExplorer)
+	require.Equal(t, []ChainID{100}, r.Mainnets())
+}
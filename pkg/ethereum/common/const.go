@@ -27,24 +27,19 @@ const (
 	ArbitrumMainnet    uint64 = 42161
 	ArbitrumGoerli     uint64 = 421613
 	ArbitrumSepolia    uint64 = 421614
-	BinanceChainID     uint64 = 56 // obsolete?
-	BinanceTestChainID uint64 = 97 // obsolete?
+	BinanceChainID     uint64 = 56
+	BinanceTestChainID uint64 = 97
+	PolygonMainnet     uint64 = 137
+	PolygonAmoy        uint64 = 80002
+	BaseMainnet        uint64 = 8453
+	BaseSepolia        uint64 = 84532
+	ZkSyncMainnet      uint64 = 324
+	ZkSyncSepolia      uint64 = 300
+	AvalancheMainnet   uint64 = 43114
 )
 
 var (
 	ZeroAddress = ethCommon.HexToAddress("0x0000000000000000000000000000000000000000")
-
-	SupportedNetworks = map[uint64]bool{
-		EthereumMainnet: true,
-		OptimismMainnet: true,
-		ArbitrumMainnet: true,
-	}
-
-	SupportedTestNetworks = map[uint64]bool{
-		EthereumSepolia: true,
-		OptimismSepolia: true,
-		ArbitrumSepolia: true,
-	}
 )
 
 type ContractType byte
@@ -56,6 +51,18 @@ const (
 	ContractTypeERC1155
 )
 
+func (c ContractType) String() string {
+	switch c {
+	case ContractTypeERC20:
+		return "erc20"
+	case ContractTypeERC721:
+		return "erc721"
+	case ContractTypeERC1155:
+		return "erc1155"
+	}
+	return "unknown"
+}
+
 func (c ChainID) String() string {
 	return strconv.FormatUint(uint64(c), 10)
 }
@@ -64,38 +71,36 @@ func (c ChainID) ToUint() uint64 {
 	return uint64(c)
 }
 
+// Config returns the chain's entry in the default chain registry.
+func (c ChainID) Config() (*ChainConfig, bool) {
+	return DefaultChainRegistry.Get(c)
+}
+
 func (c ChainID) IsMainnet() bool {
-	switch uint64(c) {
-	case EthereumMainnet, OptimismMainnet, ArbitrumMainnet:
-		return true
-	case EthereumGoerli, EthereumSepolia, OptimismGoerli, OptimismSepolia, ArbitrumGoerli, ArbitrumSepolia:
-		return false
-	case UnknownChainID:
-		return false
-	}
-	return false
+	cfg, ok := c.Config()
+	return ok && !cfg.Testnet
 }
 
-func AllChainIDs() []ChainID {
-	return []ChainID{
-		ChainID(EthereumMainnet),
-		ChainID(EthereumGoerli),
-		ChainID(EthereumSepolia),
-		ChainID(OptimismMainnet),
-		ChainID(OptimismGoerli),
-		ChainID(OptimismSepolia),
-		ChainID(ArbitrumMainnet),
-		ChainID(ArbitrumGoerli),
-		ChainID(ArbitrumSepolia),
+// AverageBlockDuration returns the chain's block time, falling back to
+// DefaultBlockDuration for chains missing from the registry.
+func (c ChainID) AverageBlockDuration() time.Duration {
+	cfg, ok := c.Config()
+	if !ok {
+		return DefaultBlockDuration
 	}
+	return cfg.BlockDuration()
+}
+
+// SupportedNetworks returns the mainnets of the default chain registry.
+func SupportedNetworks() []ChainID {
+	return DefaultChainRegistry.Mainnets()
+}
+
+// SupportedTestNetworks returns the testnets of the default chain registry.
+func SupportedTestNetworks() []ChainID {
+	return DefaultChainRegistry.Testnets()
 }
 
-var AverageBlockDurationForChain = map[ChainID]time.Duration{
-	ChainID(UnknownChainID):  time.Duration(12000) * time.Millisecond,
-	ChainID(EthereumMainnet): time.Duration(12000) * time.Millisecond,
-	ChainID(EthereumGoerli):  time.Duration(12000) * time.Millisecond,
-	ChainID(OptimismMainnet): time.Duration(400) * time.Millisecond,
-	ChainID(OptimismGoerli):  time.Duration(2000) * time.Millisecond,
-	ChainID(ArbitrumMainnet): time.Duration(300) * time.Millisecond,
-	ChainID(ArbitrumGoerli):  time.Duration(1500) * time.Millisecond,
+func AllChainIDs() []ChainID {
+	return DefaultChainRegistry.ChainIDs()
 }
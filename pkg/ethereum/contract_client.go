@@ -0,0 +1,108 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+)
+
+// ContractClient builds the transactions of a single contract, sends them
+// through a Transactor and waits for their receipts. The token clients embed
+// it next to their bindings.
+type ContractClient struct {
+	address    common.Address
+	abi        *abi.ABI
+	chainID    *big.Int
+	client     *ethclient.Client
+	transactor *Transactor
+	symbol     string
+
+	receiptPollInterval time.Duration
+}
+
+func NewContractClient(
+	address common.Address,
+	contractABI *abi.ABI,
+	chainID *big.Int,
+	client *ethclient.Client,
+	transactor *Transactor,
+) *ContractClient {
+	return &ContractClient{
+		address:             address,
+		abi:                 contractABI,
+		chainID:             chainID,
+		client:              client,
+		transactor:          transactor,
+		receiptPollInterval: DefaultReceiptPollInterval,
+	}
+}
+
+func (c *ContractClient) Address() common.Address {
+	return c.address
+}
+
+func (c *ContractClient) ABI() *abi.ABI {
+	return c.abi
+}
+
+func (c *ContractClient) ChainID() *big.Int {
+	return c.chainID
+}
+
+func (c *ContractClient) Client() *ethclient.Client {
+	return c.client
+}
+
+// Symbol is recorded with the pending transactions of the contract. It's
+// empty unless set with SetSymbol.
+func (c *ContractClient) Symbol() string {
+	return c.symbol
+}
+
+func (c *ContractClient) SetSymbol(symbol string) {
+	c.symbol = symbol
+}
+
+// SetReceiptPollInterval sets how often WaitReceipt polls the node.
+func (c *ContractClient) SetReceiptPollInterval(interval time.Duration) {
+	c.receiptPollInterval = interval
+}
+
+// CallArgs returns the arguments of a call of method sent by from. Gas and
+// fees are left to the transactor.
+func (c *ContractClient) CallArgs(from common.Address, method string, args ...interface{}) (_types.SendTxArgs, error) {
+	data, err := c.abi.Pack(method, args...)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	return c.TxArgs(from, data), nil
+}
+
+// TxArgs returns the arguments of a transaction of from to the contract with
+// already packed data.
+func (c *ContractClient) TxArgs(from common.Address, data []byte) _types.SendTxArgs {
+	to := c.address
+	return _types.SendTxArgs{
+		From:   from,
+		To:     &to,
+		Input:  data,
+		Symbol: c.symbol,
+	}
+}
+
+// Send signs args with signer and sends them, see
+// Transactor.SendTransactionWithChainID for lastUsedNonce.
+func (c *ContractClient) Send(ctx context.Context, signer Signer, args _types.SendTxArgs, lastUsedNonce int64) (_types.Hash, uint64, error) {
+	return c.transactor.SendTransactionWithChainID(ctx, c.chainID, args, signer, lastUsedNonce)
+}
+
+// WaitReceipt polls the receipt of hash until it is mined or ctx is done.
+func (c *ContractClient) WaitReceipt(ctx context.Context, hash _types.Hash) (*types.Receipt, error) {
+	return WaitReceipt(ctx, c.client, common.Hash(hash), c.receiptPollInterval)
+}
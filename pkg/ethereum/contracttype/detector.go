@@ -0,0 +1,192 @@
+package contracttype
+
+import (
+	"context"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoCode = errors.New("no contract code at address")
+
+	InterfaceIdERC165  = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InterfaceIdERC721  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceIdERC1155 = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	// ERC-165 requires supportsInterface(0xffffffff) to return false
+	interfaceIdInvalid = [4]byte{0xff, 0xff, 0xff, 0xff}
+
+	supportsInterfaceSelector = []byte{0x01, 0xff, 0xc9, 0xa7}
+	decimalsSelector          = []byte{0x31, 0x3c, 0xe5, 0x67}
+	totalSupplySelector       = []byte{0x18, 0x16, 0x0d, 0xdd}
+	balanceOfSelector         = []byte{0x70, 0xa0, 0x82, 0x31}
+	unknownSelector           = []byte{0xff, 0xff, 0xff, 0xff}
+)
+
+// Contract is the classification of a contract address. Calls to a proxy are
+// delegated to its implementation, so Type describes the implementation.
+type Contract struct {
+	Address        common.Address
+	Type           wallet_common.ContractType
+	Proxy          ProxyType
+	Implementation common.Address
+}
+
+// Detector classifies contracts into ContractType, using ERC-165 for ERC721
+// and ERC1155 and probing the calls of ERC20, which has no interface id.
+type Detector struct {
+	client *ethclient.Client
+}
+
+func NewDetector(client *ethclient.Client) *Detector {
+	return &Detector{client: client}
+}
+
+// Detect classifies address. Contracts that match no standard are returned
+// with ContractTypeUnknown.
+func (d *Detector) Detect(ctx context.Context, address common.Address) (*Contract, error) {
+	if err := d.checkCode(ctx, address); err != nil {
+		return nil, err
+	}
+
+	contract := &Contract{Address: address}
+	// follow proxies pointing at proxies to the final implementation
+	for depth, target := 0, address; ; depth++ {
+		proxy, implementation, err := d.ResolveProxy(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if proxy == ProxyNone {
+			break
+		}
+		if depth == maxProxyDepth {
+			return nil, errors.Wrap(ErrProxyLoop, address.Hex())
+		}
+		if contract.Proxy == ProxyNone {
+			contract.Proxy = proxy
+		}
+		if err := d.checkCode(ctx, implementation); err != nil {
+			return nil, errors.Wrapf(err, "implementation of %s", target.Hex())
+		}
+		contract.Implementation = implementation
+		target = implementation
+	}
+
+	contractType, err := d.detectType(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	contract.Type = contractType
+	return contract, nil
+}
+
+func (d *Detector) checkCode(ctx context.Context, address common.Address) error {
+	code, err := d.client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return errors.Wrap(ErrNoCode, address.Hex())
+	}
+	return nil
+}
+
+func (d *Detector) detectType(ctx context.Context, address common.Address) (wallet_common.ContractType, error) {
+	erc165, err := d.SupportsERC165(ctx, address)
+	if err != nil {
+		return wallet_common.ContractTypeUnknown, err
+	}
+	if erc165 {
+		for _, standard := range []struct {
+			id           [4]byte
+			contractType wallet_common.ContractType
+		}{
+			{InterfaceIdERC721, wallet_common.ContractTypeERC721},
+			{InterfaceIdERC1155, wallet_common.ContractTypeERC1155},
+		} {
+			ok, err := d.SupportsInterface(ctx, address, standard.id)
+			if err != nil {
+				return wallet_common.ContractTypeUnknown, err
+			}
+			if ok {
+				return standard.contractType, nil
+			}
+		}
+	}
+
+	ok, err := d.probeERC20(ctx, address)
+	if err != nil || !ok {
+		return wallet_common.ContractTypeUnknown, err
+	}
+	return wallet_common.ContractTypeERC20, nil
+}
+
+// SupportsERC165 runs the ERC-165 detection sequence, which guards against
+// contracts whose fallback returns true for any call.
+func (d *Detector) SupportsERC165(ctx context.Context, address common.Address) (bool, error) {
+	ok, err := d.SupportsInterface(ctx, address, InterfaceIdERC165)
+	if err != nil || !ok {
+		return false, err
+	}
+	ok, err = d.SupportsInterface(ctx, address, interfaceIdInvalid)
+	return err == nil && !ok, err
+}
+
+// SupportsInterface calls supportsInterface(id). A revert or a malformed
+// result counts as false.
+func (d *Detector) SupportsInterface(ctx context.Context, address common.Address, id [4]byte) (bool, error) {
+	input := make([]byte, 4+32)
+	copy(input, supportsInterfaceSelector)
+	copy(input[4:], id[:])
+	result, ok, err := d.call(ctx, address, input)
+	if err != nil || !ok {
+		return false, err
+	}
+	return len(result) == 32 && common.BytesToHash(result) == common.BigToHash(common.Big1), nil
+}
+
+// probeERC20 checks that decimals(), totalSupply() and balanceOf(address(0))
+// all return a single word, with decimals small enough to be a uint8, and that
+// the contract doesn't answer any call with a word.
+func (d *Detector) probeERC20(ctx context.Context, address common.Address) (bool, error) {
+	result, ok, err := d.call(ctx, address, unknownSelector)
+	if err != nil || (ok && len(result) == 32) {
+		return false, err
+	}
+
+	probes := [][]byte{
+		decimalsSelector,
+		totalSupplySelector,
+		append(append([]byte{}, balanceOfSelector...), make([]byte, 32)...),
+	}
+	for i, input := range probes {
+		result, ok, err := d.call(ctx, address, input)
+		if err != nil || !ok {
+			return false, err
+		}
+		if len(result) != 32 {
+			return false, nil
+		}
+		if i == 0 && common.BytesToHash(result).Big().Cmp(common.Big256) >= 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// call returns ok false when the call reverts, and an error only when the
+// node could not be queried.
+func (d *Detector) call(ctx context.Context, address common.Address, input []byte) ([]byte, bool, error) {
+	result, err := d.client.CallContract(ctx, goethereum.CallMsg{To: &address, Data: input}, nil)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return result, true, nil
+}
@@ -0,0 +1,181 @@
+package contracttype
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var errReverted = errors.New("execution reverted")
+
+type fakeContract struct {
+	// call returns the result of input, or nil to revert
+	call    func(input []byte) []byte
+	storage map[common.Hash]common.Address
+	// err fails every call, like a node that can't run it
+	err error
+}
+
+// fakeContractsAPI serves code, storage and calls of fake contracts. Calls
+// to a proxy run its implementation, as delegatecall would.
+type fakeContractsAPI struct {
+	contracts map[common.Address]*fakeContract
+}
+
+func (api *fakeContractsAPI) GetCode(address common.Address, block string) hexutil.Bytes {
+	if _, ok := api.contracts[address]; ok {
+		return hexutil.Bytes{0x60, 0x80}
+	}
+	return hexutil.Bytes{}
+}
+
+func (api *fakeContractsAPI) GetStorageAt(address common.Address, slot common.Hash, block string) hexutil.Bytes {
+	var value common.Hash
+	if contract, ok := api.contracts[address]; ok {
+		value = common.BytesToHash(contract.storage[slot].Bytes())
+	}
+	return value[:]
+}
+
+func (api *fakeContractsAPI) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	address := common.HexToAddress(args["to"].(string))
+	input := hexutil.MustDecode(args["input"].(string))
+	for depth := 0; depth < 8; depth++ {
+		contract, ok := api.contracts[address]
+		if !ok {
+			return nil, nil
+		}
+		if implementation, ok := contract.storage[EIP1967ImplementationSlot]; ok {
+			address = implementation
+			continue
+		}
+		if beacon, ok := contract.storage[EIP1967BeaconSlot]; ok {
+			address = common.BytesToAddress(api.contracts[beacon].call(beaconImplementationSelector))
+			continue
+		}
+		if contract.err != nil {
+			return nil, contract.err
+		}
+		if result := contract.call(input); result != nil {
+			return result, nil
+		}
+		return nil, errReverted
+	}
+	return nil, errReverted
+}
+
+func word(v int64) []byte {
+	return common.BigToHash(big.NewInt(v)).Bytes()
+}
+
+func erc165(ids ...[4]byte) func([]byte) []byte {
+	return func(input []byte) []byte {
+		if !bytes.Equal(input[:4], supportsInterfaceSelector) {
+			return nil
+		}
+		for _, id := range append(ids, InterfaceIdERC165) {
+			if bytes.Equal(input[4:8], id[:]) {
+				return word(1)
+			}
+		}
+		return word(0)
+	}
+}
+
+func erc20(decimals int64) func([]byte) []byte {
+	return func(input []byte) []byte {
+		switch {
+		case bytes.Equal(input[:4], decimalsSelector):
+			return word(decimals)
+		case bytes.Equal(input[:4], totalSupplySelector), bytes.Equal(input[:4], balanceOfSelector):
+			return word(1_000_000)
+		}
+		return nil
+	}
+}
+
+func TestDetect(t *testing.T) {
+	var (
+		token       = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		nft         = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		multiToken  = common.HexToAddress("0x1000000000000000000000000000000000000003")
+		catchAll    = common.HexToAddress("0x1000000000000000000000000000000000000004")
+		empty       = common.HexToAddress("0x1000000000000000000000000000000000000005")
+		badDecimals = common.HexToAddress("0x1000000000000000000000000000000000000006")
+		proxy       = common.HexToAddress("0x2000000000000000000000000000000000000001")
+		beacon      = common.HexToAddress("0x2000000000000000000000000000000000000002")
+		beaconProxy = common.HexToAddress("0x2000000000000000000000000000000000000003")
+		uups        = common.HexToAddress("0x2000000000000000000000000000000000000004")
+		loopA       = common.HexToAddress("0x2000000000000000000000000000000000000005")
+		loopB       = common.HexToAddress("0x2000000000000000000000000000000000000006")
+		eoa         = common.HexToAddress("0x3000000000000000000000000000000000000001")
+		unavailable = common.HexToAddress("0x3000000000000000000000000000000000000002")
+	)
+
+	api := &fakeContractsAPI{contracts: map[common.Address]*fakeContract{
+		token:       {call: erc20(6)},
+		nft:         {call: erc165(InterfaceIdERC721)},
+		multiToken:  {call: erc165(InterfaceIdERC1155)},
+		catchAll:    {call: func([]byte) []byte { return word(1) }},
+		empty:       {call: func([]byte) []byte { return []byte{} }},
+		badDecimals: {call: erc20(1 << 20)},
+		proxy:       {storage: map[common.Hash]common.Address{EIP1967ImplementationSlot: token}},
+		beacon: {call: func(input []byte) []byte {
+			return common.BytesToHash(nft[:]).Bytes()
+		}},
+		beaconProxy: {storage: map[common.Hash]common.Address{EIP1967BeaconSlot: beacon}},
+		uups:        {call: erc20(18), storage: map[common.Hash]common.Address{EIP1822ProxiableSlot: token}},
+		loopA:       {storage: map[common.Hash]common.Address{ZeppelinOSImplementationSlot: loopB}},
+		loopB:       {storage: map[common.Hash]common.Address{ZeppelinOSImplementationSlot: loopA}},
+		unavailable: {err: errors.New("upstream timeout")},
+	}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	t.Cleanup(server.Stop)
+	detector := NewDetector(ethclient.NewClient(rpc.DialInProc(server)))
+
+	testCases := []struct {
+		address        common.Address
+		contractType   wallet_common.ContractType
+		proxy          ProxyType
+		implementation common.Address
+	}{
+		{token, wallet_common.ContractTypeERC20, ProxyNone, common.Address{}},
+		{nft, wallet_common.ContractTypeERC721, ProxyNone, common.Address{}},
+		{multiToken, wallet_common.ContractTypeERC1155, ProxyNone, common.Address{}},
+		// answers true to every call, including supportsInterface(0xffffffff)
+		{catchAll, wallet_common.ContractTypeUnknown, ProxyNone, common.Address{}},
+		{empty, wallet_common.ContractTypeUnknown, ProxyNone, common.Address{}},
+		{badDecimals, wallet_common.ContractTypeUnknown, ProxyNone, common.Address{}},
+		{proxy, wallet_common.ContractTypeERC20, ProxyEIP1967, token},
+		{beaconProxy, wallet_common.ContractTypeERC721, ProxyBeacon, nft},
+		{uups, wallet_common.ContractTypeERC20, ProxyEIP1822, token},
+	}
+	for _, tc := range testCases {
+		contract, err := detector.Detect(context.Background(), tc.address)
+		require.NoError(t, err, tc.address.Hex())
+		require.Equal(t, tc.contractType, contract.Type, tc.address.Hex())
+		require.Equal(t, tc.proxy, contract.Proxy, tc.address.Hex())
+		require.Equal(t, tc.implementation, contract.Implementation, tc.address.Hex())
+	}
+
+	_, err := detector.Detect(context.Background(), eoa)
+	require.ErrorIs(t, err, ErrNoCode)
+	_, err = detector.Detect(context.Background(), loopA)
+	require.ErrorIs(t, err, ErrProxyLoop)
+	// node errors aren't mistaken for reverts of unsupported methods
+	_, err = detector.Detect(context.Background(), unavailable)
+	require.ErrorContains(t, err, "upstream timeout")
+
+	require.Equal(t, "erc721", wallet_common.ContractTypeERC721.String())
+	require.Equal(t, "beacon", ProxyBeacon.String())
+}
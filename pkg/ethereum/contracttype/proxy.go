@@ -0,0 +1,105 @@
+package contracttype
+
+import (
+	"context"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+type ProxyType byte
+
+const (
+	ProxyNone ProxyType = iota
+	// ProxyEIP1967 stores its implementation in the EIP-1967 implementation
+	// slot, as transparent and UUPS proxies do.
+	ProxyEIP1967
+	// ProxyEIP1822 stores its implementation in the slot of keccak256("PROXIABLE").
+	ProxyEIP1822
+	// ProxyBeacon stores an EIP-1967 beacon whose implementation() is shared
+	// by all its proxies.
+	ProxyBeacon
+	// ProxyZeppelinOS is the pre-EIP-1967 OpenZeppelin proxy, still used by
+	// tokens such as USDC.
+	ProxyZeppelinOS
+)
+
+// maxProxyDepth bounds how many proxies pointing at proxies are followed.
+const maxProxyDepth = 4
+
+var (
+	ErrProxyLoop = errors.New("proxy chain too long")
+
+	// bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+	EIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// bytes32(uint256(keccak256("eip1967.proxy.beacon")) - 1)
+	EIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	// keccak256("PROXIABLE")
+	EIP1822ProxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+	// keccak256("org.zeppelinos.proxy.implementation")
+	ZeppelinOSImplementationSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+
+	// implementation()
+	beaconImplementationSelector = []byte{0x5c, 0x60, 0xda, 0x1b}
+)
+
+func (p ProxyType) String() string {
+	switch p {
+	case ProxyNone:
+		return "none"
+	case ProxyEIP1967:
+		return "eip1967"
+	case ProxyEIP1822:
+		return "eip1822"
+	case ProxyBeacon:
+		return "beacon"
+	case ProxyZeppelinOS:
+		return "zeppelinos"
+	}
+	return "unknown"
+}
+
+// ResolveProxy returns the proxy type of address and the implementation it
+// delegates to. A contract that isn't a proxy returns ProxyNone and the zero
+// address.
+func (d *Detector) ResolveProxy(ctx context.Context, address common.Address) (ProxyType, common.Address, error) {
+	for _, slot := range []struct {
+		proxy ProxyType
+		slot  common.Hash
+	}{
+		{ProxyEIP1967, EIP1967ImplementationSlot},
+		{ProxyBeacon, EIP1967BeaconSlot},
+		{ProxyEIP1822, EIP1822ProxiableSlot},
+		{ProxyZeppelinOS, ZeppelinOSImplementationSlot},
+	} {
+		value, err := d.client.StorageAt(ctx, address, slot.slot, nil)
+		if err != nil {
+			return ProxyNone, common.Address{}, err
+		}
+		target := common.BytesToAddress(value)
+		if target == (common.Address{}) {
+			continue
+		}
+		if slot.proxy == ProxyBeacon {
+			implementation, err := d.beaconImplementation(ctx, target)
+			if err != nil {
+				return ProxyNone, common.Address{}, errors.Wrapf(err, "beacon %s", target.Hex())
+			}
+			target = implementation
+		}
+		return slot.proxy, target, nil
+	}
+	return ProxyNone, common.Address{}, nil
+}
+
+func (d *Detector) beaconImplementation(ctx context.Context, beacon common.Address) (common.Address, error) {
+	result, err := d.client.CallContract(ctx, goethereum.CallMsg{To: &beacon, Data: beaconImplementationSelector}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(result) != 32 {
+		return common.Address{}, errors.Errorf("implementation() returned %d bytes", len(result))
+	}
+	return common.BytesToAddress(result), nil
+}
@@ -0,0 +1,418 @@
+package eip712
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+)
+
+const DomainType = "EIP712Domain"
+
+type (
+	TypedData = apitypes.TypedData
+	Types     = apitypes.Types
+	Type      = apitypes.Type
+	Domain    = apitypes.TypedDataDomain
+	Message   = apitypes.TypedDataMessage
+)
+
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// domainFields lists the EIP712Domain fields in the order mandated by EIP-712.
+var domainFields = []Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+var (
+	identifierRegexp = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z_$0-9]*$`)
+	arrayTypeRegexp  = regexp.MustCompile(`^(.+)\[([1-9][0-9]*)?\]$`)
+	atomicTypeRegexp = regexp.MustCompile(`^(address|bool|string|bytes([1-9]|[12][0-9]|3[0-2])?|u?int(8|16|24|32|40|48|56|64|72|80|88|96|104|112|120|128|136|144|152|160|168|176|184|192|200|208|216|224|232|240|248|256)?)$`)
+)
+
+// Parse decodes an eth_signTypedData_v4 payload and validates it. Numbers are
+// decoded losslessly, so uint256 values may be given as JSON numbers.
+func Parse(data []byte) (*TypedData, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var typedData TypedData
+	if err := decoder.Decode(&typedData); err != nil {
+		return nil, errors.Wrap(ErrInvalidTypedData, err.Error())
+	}
+	if typedData.Message == nil {
+		typedData.Message = Message{}
+	}
+	normalizeNumbers(map[string]interface{}(typedData.Message))
+
+	if err := Validate(&typedData); err != nil {
+		return nil, err
+	}
+	return &typedData, nil
+}
+
+// normalizeNumbers turns json.Number into decimal strings in place, which
+// apitypes parses without going through float64.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	}
+	return v
+}
+
+func invalid(format string, args ...interface{}) error {
+	return errors.Wrapf(ErrInvalidTypedData, format, args...)
+}
+
+// Validate checks the types, the domain and the message against each other.
+func Validate(typedData *TypedData) error {
+	if err := validateTypes(typedData.Types); err != nil {
+		return err
+	}
+	if err := validateDomain(typedData); err != nil {
+		return err
+	}
+
+	if typedData.PrimaryType == "" {
+		return invalid("primaryType is required")
+	}
+	if typedData.PrimaryType == DomainType {
+		return invalid("primaryType can't be %s", DomainType)
+	}
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return invalid("primaryType %q is undefined", typedData.PrimaryType)
+	}
+
+	return validateMessage(typedData.Types, typedData.PrimaryType, typedData.Message, typedData.PrimaryType)
+}
+
+// parseArray splits an array type into its element type and its length, -1
+// for dynamic arrays. Arrays of arrays are split from the right, so the
+// element type of uint256[2][] is uint256[2].
+func parseArray(typ string) (elem string, length int, ok bool) {
+	match := arrayTypeRegexp.FindStringSubmatch(typ)
+	if match == nil {
+		return typ, 0, false
+	}
+	if match[2] == "" {
+		return match[1], -1, true
+	}
+	length, err := strconv.Atoi(match[2])
+	if err != nil {
+		return typ, 0, false
+	}
+	return match[1], length, true
+}
+
+// baseType strips all array suffixes from typ.
+func baseType(typ string) string {
+	for {
+		elem, _, ok := parseArray(typ)
+		if !ok {
+			return typ
+		}
+		typ = elem
+	}
+}
+
+func validateTypes(types Types) error {
+	if _, ok := types[DomainType]; !ok {
+		return invalid("%s type is required", DomainType)
+	}
+
+	for name, fields := range types {
+		if !identifierRegexp.MatchString(name) {
+			return invalid("invalid type name %q", name)
+		}
+		if atomicTypeRegexp.MatchString(name) {
+			return invalid("type name %q shadows an atomic type", name)
+		}
+
+		seen := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			if !identifierRegexp.MatchString(field.Name) {
+				return invalid("type %s: invalid field name %q", name, field.Name)
+			}
+			if seen[field.Name] {
+				return invalid("type %s: duplicate field %q", name, field.Name)
+			}
+			seen[field.Name] = true
+
+			// structs may reference themselves, recursion ends at null values
+			base := baseType(field.Type)
+			if strings.ContainsAny(base, "[]") {
+				return invalid("type %s: field %s: invalid array type %q", name, field.Name, field.Type)
+			}
+			if atomicTypeRegexp.MatchString(base) {
+				continue
+			}
+			if _, ok := types[base]; !ok {
+				return invalid("type %s: field %s references undefined type %q", name, field.Name, field.Type)
+			}
+			if base == DomainType {
+				return invalid("type %s: field %s references %s", name, field.Name, DomainType)
+			}
+		}
+	}
+	return nil
+}
+
+// DomainTypes returns the EIP712Domain fields of the fields set in domain, in
+// the canonical order and with their canonical types.
+func DomainTypes(domain Domain) []Type {
+	set := map[string]bool{
+		"name":              domain.Name != "",
+		"version":           domain.Version != "",
+		"chainId":           domain.ChainId != nil,
+		"verifyingContract": domain.VerifyingContract != "",
+		"salt":              domain.Salt != "",
+	}
+
+	var fields []Type
+	for _, field := range domainFields {
+		if set[field.Name] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// validateDomain requires EIP712Domain to declare exactly the domain fields that
+// are set, in the canonical order and with their canonical types.
+func validateDomain(typedData *TypedData) error {
+	domain := typedData.Domain
+	expected := DomainTypes(domain)
+	if len(expected) == 0 {
+		return invalid("domain is empty")
+	}
+
+	declared := typedData.Types[DomainType]
+	if len(declared) != len(expected) {
+		return invalid("%s declares %d fields but the domain sets %d", DomainType, len(declared), len(expected))
+	}
+	for i := range expected {
+		if declared[i] != expected[i] {
+			return invalid("%s field %d: expected %s %s, got %s %s", DomainType, i, expected[i].Type, expected[i].Name, declared[i].Type, declared[i].Name)
+		}
+	}
+
+	if domain.VerifyingContract != "" && !common.IsHexAddress(domain.VerifyingContract) {
+		return invalid("domain verifyingContract %q is not an address", domain.VerifyingContract)
+	}
+	if domain.Salt != "" {
+		salt, err := hexutil.Decode(domain.Salt)
+		if err != nil || len(salt) != 32 {
+			return invalid("domain salt must be 32 bytes")
+		}
+	}
+	if domain.ChainId != nil && (*big.Int)(domain.ChainId).Sign() <= 0 {
+		return invalid("domain chainId must be positive")
+	}
+	return nil
+}
+
+func validateMessage(types Types, typ string, data map[string]interface{}, path string) error {
+	fields := types[typ]
+	for key := range data {
+		if !hasField(fields, key) {
+			return invalid("%s: unknown field %q", path, key)
+		}
+	}
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return invalid("%s: missing field %q", path, field.Name)
+		}
+		if err := validateValue(types, field.Type, value, path+"."+field.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateValue(types Types, typ string, value interface{}, path string) error {
+	if elem, length, ok := parseArray(typ); ok {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			return invalid("%s: expected array", path)
+		}
+		if length >= 0 && items.Len() != length {
+			return invalid("%s: expected %d items, got %d", path, length, items.Len())
+		}
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i).Interface()
+			if err := validateValue(types, elem, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, ok := types[typ]; ok {
+		if value == nil {
+			return nil
+		}
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid("%s: expected %s object", path, typ)
+		}
+		return validateMessage(types, typ, nested, path)
+	}
+
+	if s, ok := value.(string); ok && typ == "address" && !common.IsHexAddress(s) {
+		return invalid("%s: %q is not an address", path, s)
+	}
+	return nil
+}
+
+func hasField(fields []Type, name string) bool {
+	for _, field := range fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainSeparator returns hashStruct(EIP712Domain).
+func DomainSeparator(typedData *TypedData) (common.Hash, error) {
+	return HashStruct(typedData, DomainType, typedData.Domain.Map())
+}
+
+// HashStruct returns hashStruct(data) for the given struct type.
+func HashStruct(typedData *TypedData, primaryType string, data map[string]interface{}) (common.Hash, error) {
+	encoded := TypeHash(typedData, primaryType).Bytes()
+	for _, field := range typedData.Types[primaryType] {
+		value, err := encodeValue(typedData, field.Type, data[field.Name])
+		if err != nil {
+			return common.Hash{}, errors.Wrapf(ErrInvalidTypedData, "%s.%s: %s", primaryType, field.Name, err)
+		}
+		encoded = append(encoded, value...)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// encodeValue returns the 32-byte encoding of value. Arrays, fixed or nested,
+// are hashed item by item and null structs encode as zero, as in MetaMask's
+// eth_signTypedData_v4.
+func encodeValue(typedData *TypedData, typ string, value interface{}) ([]byte, error) {
+	if elem, _, ok := parseArray(typ); ok {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			return nil, errors.Errorf("expected %s, got %v", typ, value)
+		}
+		var encoded []byte
+		for i := 0; i < items.Len(); i++ {
+			item, err := encodeValue(typedData, elem, items.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, item...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if _, ok := typedData.Types[typ]; ok {
+		if value == nil {
+			return make([]byte, 32), nil
+		}
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected %s, got %v", typ, value)
+		}
+		hash, err := HashStruct(typedData, typ, nested)
+		if err != nil {
+			return nil, err
+		}
+		return hash[:], nil
+	}
+
+	return typedData.EncodePrimitiveValue(typ, value, 0)
+}
+
+// EncodeType returns encodeType(primaryType): the primary type followed by the
+// struct types it references, directly or not, sorted by name.
+func EncodeType(typedData *TypedData, primaryType string) string {
+	deps := map[string]bool{}
+	var collect func(typ string)
+	collect = func(typ string) {
+		for _, field := range typedData.Types[typ] {
+			base := baseType(field.Type)
+			if _, ok := typedData.Types[base]; ok && !deps[base] && base != primaryType {
+				deps[base] = true
+				collect(base)
+			}
+		}
+	}
+	collect(primaryType)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer strings.Builder
+	for _, name := range append([]string{primaryType}, names...) {
+		buffer.WriteString(name)
+		buffer.WriteString("(")
+		for i, field := range typedData.Types[name] {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+			buffer.WriteString(field.Type)
+			buffer.WriteString(" ")
+			buffer.WriteString(field.Name)
+		}
+		buffer.WriteString(")")
+	}
+	return buffer.String()
+}
+
+// TypeHash returns keccak256(encodeType(primaryType)).
+func TypeHash(typedData *TypedData, primaryType string) common.Hash {
+	return crypto.Keccak256Hash([]byte(EncodeType(typedData, primaryType)))
+}
+
+// Hash returns the digest that gets signed:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func Hash(typedData *TypedData) (common.Hash, error) {
+	if err := Validate(typedData); err != nil {
+		return common.Hash{}, err
+	}
+
+	domainSeparator, err := DomainSeparator(typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	messageHash, err := HashStruct(typedData, typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator[:], messageHash[:])), nil
+}
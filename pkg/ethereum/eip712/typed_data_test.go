@@ -0,0 +1,165 @@
+package eip712
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// mailTypedData is the example of https://eips.ethereum.org/EIPS/eip-712
+// (assets/eip-712/Example.js).
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestEIP712ReferenceVectors(t *testing.T) {
+	typedData, err := Parse([]byte(mailTypedData))
+	require.NoError(t, err)
+
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", EncodeType(typedData, "Mail"))
+	require.Equal(t, common.HexToHash("0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"), TypeHash(typedData, "Mail"))
+
+	domainSeparator, err := DomainSeparator(typedData)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"), domainSeparator)
+
+	messageHash, err := HashStruct(typedData, typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"), messageHash)
+
+	hash, err := Hash(typedData)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"), hash)
+
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), crypto.PubkeyToAddress(key.PublicKey))
+
+	sig, err := crypto.Sign(hash[:], key)
+	require.NoError(t, err)
+	require.Equal(t, "0x"+
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+
+		"01", hexutil.Encode(sig))
+}
+
+func TestValidate(t *testing.T) {
+	valid, err := Parse([]byte(mailTypedData))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		mutate func(td *TypedData)
+	}{
+		{"missingDomainType", func(td *TypedData) { delete(td.Types, DomainType) }},
+		{"domainFieldOrder", func(td *TypedData) {
+			d := td.Types[DomainType]
+			d[0], d[1] = d[1], d[0]
+		}},
+		{"domainFieldNotSet", func(td *TypedData) { td.Domain.Version = "" }},
+		{"undefinedPrimaryType", func(td *TypedData) { td.PrimaryType = "Letter" }},
+		{"undefinedReference", func(td *TypedData) { td.Types["Mail"][0].Type = "Human" }},
+		{"duplicateField", func(td *TypedData) { td.Types["Person"][1].Name = "name" }},
+		{"badArray", func(td *TypedData) { td.Types["Person"][1].Type = "address[0]" }},
+		{"unclosedArray", func(td *TypedData) { td.Types["Person"][1].Type = "address[2" }},
+		{"arrayLength", func(td *TypedData) {
+			td.Types["Person"][1].Type = "address[2]"
+			td.Message["to"].(map[string]interface{})["wallet"] = []interface{}{"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"}
+		}},
+		{"missingField", func(td *TypedData) { delete(td.Message, "contents") }},
+		{"unknownField", func(td *TypedData) { td.Message["cc"] = "Alice" }},
+		{"badAddress", func(td *TypedData) { td.Message["to"].(map[string]interface{})["wallet"] = "0x1234" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typedData, err := Parse([]byte(mailTypedData))
+			require.NoError(t, err)
+			tc.mutate(typedData)
+			require.ErrorIs(t, Validate(typedData), ErrInvalidTypedData)
+		})
+	}
+
+	require.NoError(t, Validate(valid))
+}
+
+func word(v int64) []byte {
+	return common.BigToHash(big.NewInt(v)).Bytes()
+}
+
+func TestArraysAndRecursiveTypes(t *testing.T) {
+	typedData, err := Parse([]byte(`{
+  "types": {
+    "EIP712Domain": [{"name": "name", "type": "string"}],
+    "Grid": [
+      {"name": "corners", "type": "address[2]"},
+      {"name": "rows", "type": "uint256[2][]"},
+      {"name": "head", "type": "Node"}
+    ],
+    "Node": [
+      {"name": "value", "type": "uint256"},
+      {"name": "next", "type": "Node"}
+    ]
+  },
+  "primaryType": "Grid",
+  "domain": {"name": "Grid"},
+  "message": {
+    "corners": ["0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"],
+    "rows": [[1, 2], [3, 4], [5, 6]],
+    "head": {"value": 7, "next": {"value": 8, "next": null}}
+  }
+}`))
+	require.NoError(t, err)
+
+	require.Equal(t, "Grid(address[2] corners,uint256[2][] rows,Node head)Node(uint256 value,Node next)", EncodeType(typedData, "Grid"))
+	require.Equal(t, "Node(uint256 value,Node next)", EncodeType(typedData, "Node"))
+
+	// the encoding of https://eips.ethereum.org/EIPS/eip-712#definition-of-encodedata
+	// spelled out
+	nodeType := crypto.Keccak256([]byte("Node(uint256 value,Node next)"))
+	last := crypto.Keccak256(nodeType, word(8), make([]byte, 32))
+	head := crypto.Keccak256(nodeType, word(7), last)
+	rows := crypto.Keccak256(
+		crypto.Keccak256(word(1), word(2)),
+		crypto.Keccak256(word(3), word(4)),
+		crypto.Keccak256(word(5), word(6)),
+	)
+	corners := crypto.Keccak256(word(1), word(2))
+	expected := crypto.Keccak256Hash(crypto.Keccak256([]byte(EncodeType(typedData, "Grid"))), corners, rows, head)
+
+	hash, err := HashStruct(typedData, typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+	require.Equal(t, expected, hash)
+}
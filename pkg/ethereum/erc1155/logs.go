@@ -0,0 +1,82 @@
+package erc1155
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotTransferLog = errors.New("not an ERC1155 transfer log")
+
+	TransferSingleTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+	TransferBatchTopic  = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+	ApprovalForAllTopic = common.HexToHash("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31")
+)
+
+// ParseMovements serves logs of any contract, so the filterer is unbound
+var filterer, _ = NewIERC1155Filterer(common.Address{}, nil)
+
+// Movement is the transfer of Value tokens of type ID. A TransferBatch log
+// yields one Movement per token type. Mints come from and burns go to the
+// zero address.
+type Movement struct {
+	Contract common.Address
+	Operator common.Address
+	From     common.Address
+	To       common.Address
+	ID       *big.Int
+	Value    *big.Int
+	TxHash   common.Hash
+	LogIndex uint
+}
+
+// ParseMovements decodes a TransferSingle or TransferBatch log.
+func ParseMovements(log types.Log) ([]Movement, error) {
+	if len(log.Topics) != 4 {
+		return nil, ErrNotTransferLog
+	}
+	movement := func(id, value *big.Int) Movement {
+		return Movement{
+			Contract: log.Address,
+			Operator: common.BytesToAddress(log.Topics[1][:]),
+			From:     common.BytesToAddress(log.Topics[2][:]),
+			To:       common.BytesToAddress(log.Topics[3][:]),
+			ID:       id,
+			Value:    value,
+			TxHash:   log.TxHash,
+			LogIndex: log.Index,
+		}
+	}
+
+	switch log.Topics[0] {
+	case TransferSingleTopic:
+		transfer, err := filterer.ParseTransferSingle(log)
+		if err != nil {
+			return nil, errors.Wrap(ErrNotTransferLog, err.Error())
+		}
+		return []Movement{movement(transfer.Id, transfer.Value)}, nil
+	case TransferBatchTopic:
+		transfer, err := filterer.ParseTransferBatch(log)
+		if err != nil {
+			return nil, errors.Wrap(ErrNotTransferLog, err.Error())
+		}
+		if len(transfer.Ids) != len(transfer.Values) {
+			return nil, errors.Wrapf(ErrLengthMismatch, "log %d of tx %s", log.Index, log.TxHash.Hex())
+		}
+		movements := make([]Movement, len(transfer.Ids))
+		for i := range transfer.Ids {
+			movements[i] = movement(transfer.Ids[i], transfer.Values[i])
+		}
+		return movements, nil
+	}
+	return nil, ErrNotTransferLog
+}
+
+// ParseApprovalForAll decodes an ApprovalForAll log, which ERC721 declares
+// identically.
+func ParseApprovalForAll(log types.Log) (*IERC1155ApprovalForAll, error) {
+	return filterer.ParseApprovalForAll(log)
+}
@@ -0,0 +1,51 @@
+package erc1155
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMovements(t *testing.T) {
+	parsed, err := IERC1155MetaData.GetAbi()
+	require.NoError(t, err)
+	require.Equal(t, parsed.Events["TransferSingle"].ID, TransferSingleTopic)
+	require.Equal(t, parsed.Events["TransferBatch"].ID, TransferBatchTopic)
+	require.Equal(t, parsed.Events["ApprovalForAll"].ID, ApprovalForAllTopic)
+
+	minter := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	holder := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data, err := parsed.Events["TransferBatch"].Inputs.NonIndexed().Pack([]*big.Int{big.NewInt(7)}, []*big.Int{big.NewInt(3)})
+	require.NoError(t, err)
+	mint := types.Log{
+		Topics: []common.Hash{TransferBatchTopic, common.BytesToHash(minter[:]), {}, common.BytesToHash(holder[:])},
+		Data:   data,
+		Index:  4,
+	}
+	movements, err := ParseMovements(mint)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	require.Equal(t, common.Address{}, movements[0].From)
+	require.Equal(t, holder, movements[0].To)
+	require.Equal(t, uint(4), movements[0].LogIndex)
+
+	// mismatched arrays
+	data, err = parsed.Events["TransferBatch"].Inputs.NonIndexed().Pack([]*big.Int{big.NewInt(7)}, []*big.Int{})
+	require.NoError(t, err)
+	mint.Data = data
+	_, err = ParseMovements(mint)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+
+	// ERC20 Transfer
+	_, err = ParseMovements(types.Log{
+		Topics: []common.Hash{common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), {}, {}},
+		Data:   common.Hash{}.Bytes(),
+	})
+	require.ErrorIs(t, err, ErrNotTransferLog)
+
+	require.Equal(t, "ipfs://x/0000000000000000000000000000000000000000000000000000000000000001", ExpandURI("ipfs://x/{id}", big.NewInt(1)))
+	require.Equal(t, "ipfs://x/1", ExpandURI("ipfs://x/1", big.NewInt(1)))
+}
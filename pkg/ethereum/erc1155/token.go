@@ -0,0 +1,154 @@
+package erc1155
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+var ErrLengthMismatch = errors.New("ids and values have different lengths")
+
+// Token reads balances of an ERC1155 contract and sends its transfers through
+// a Transactor.
+type Token struct {
+	*ethereum.ContractClient
+	contract *IERC1155
+}
+
+func NewToken(
+	address common.Address,
+	chainID *big.Int,
+	client *ethclient.Client,
+	transactor *ethereum.Transactor,
+) (*Token, error) {
+	contract, err := NewIERC1155(address, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := IERC1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		ContractClient: ethereum.NewContractClient(address, parsed, chainID, client, transactor),
+		contract:       contract,
+	}, nil
+}
+
+func (t *Token) BalanceOf(ctx context.Context, account common.Address, id *big.Int) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, account, id)
+}
+
+// BalanceOfBatch returns the balance of accounts[i] in token type ids[i].
+func (t *Token) BalanceOfBatch(ctx context.Context, accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, errors.Wrapf(ErrLengthMismatch, "%d accounts and %d ids", len(accounts), len(ids))
+	}
+	return t.contract.BalanceOfBatch(&bind.CallOpts{Context: ctx}, accounts, ids)
+}
+
+func (t *Token) IsApprovedForAll(ctx context.Context, account common.Address, operator common.Address) (bool, error) {
+	return t.contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, account, operator)
+}
+
+// URI returns the metadata URI of token type id with the {id} placeholder
+// substituted. It fails for contracts without the metadata extension.
+func (t *Token) URI(ctx context.Context, id *big.Int) (string, error) {
+	uri, err := t.contract.Uri(&bind.CallOpts{Context: ctx}, id)
+	if err != nil {
+		return "", err
+	}
+	return ExpandURI(uri, id), nil
+}
+
+// ExpandURI replaces every {id} in uri with id as 64 lowercase hex digits, as
+// specified by the ERC1155 metadata extension.
+func ExpandURI(uri string, id *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id))
+}
+
+// SafeTransferFromArgs returns the arguments of a transfer of value tokens of
+// type id sent by sender, the owner or an approved operator.
+func (t *Token) SafeTransferFromArgs(sender common.Address, from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (_types.SendTxArgs, error) {
+	return t.CallArgs(sender, "safeTransferFrom", from, to, id, value, nonNil(data))
+}
+
+// SafeBatchTransferFromArgs returns the arguments of a transfer of values[i]
+// tokens of type ids[i].
+func (t *Token) SafeBatchTransferFromArgs(sender common.Address, from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (_types.SendTxArgs, error) {
+	if len(ids) != len(values) {
+		return _types.SendTxArgs{}, errors.Wrapf(ErrLengthMismatch, "%d ids and %d values", len(ids), len(values))
+	}
+	return t.CallArgs(sender, "safeBatchTransferFrom", from, to, ids, values, nonNil(data))
+}
+
+// SetApprovalForAllArgs returns the arguments of an operator approval. ERC1155
+// has no per-id approvals, so it's the only way to let others transfer.
+func (t *Token) SetApprovalForAllArgs(owner common.Address, operator common.Address, approved bool) (_types.SendTxArgs, error) {
+	return t.CallArgs(owner, "setApprovalForAll", operator, approved)
+}
+
+// nonNil keeps the abi encoder from rejecting a nil bytes argument.
+func nonNil(data []byte) []byte {
+	if data == nil {
+		return []byte{}
+	}
+	return data
+}
+
+// SafeTransferFrom transfers value tokens of type id from from to to, signed
+// by signer.
+func (t *Token) SafeTransferFrom(ctx context.Context, signer ethereum.Signer, from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (_types.Hash, error) {
+	args, err := t.SafeTransferFromArgs(signer.Address(), from, to, id, value, data)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// SafeBatchTransferFrom transfers several token types from from to to in one
+// transaction, signed by signer.
+func (t *Token) SafeBatchTransferFrom(ctx context.Context, signer ethereum.Signer, from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (_types.Hash, error) {
+	args, err := t.SafeBatchTransferFromArgs(signer.Address(), from, to, ids, values, data)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// SetApprovalForAll lets operator transfer any amount of every token type of
+// signer, or revokes it.
+func (t *Token) SetApprovalForAll(ctx context.Context, signer ethereum.Signer, operator common.Address, approved bool) (_types.Hash, error) {
+	args, err := t.SetApprovalForAllArgs(signer.Address(), operator, approved)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// Movements returns the token movements of this contract in receipt, in log
+// order.
+func (t *Token) Movements(receipt *types.Receipt) []Movement {
+	var movements []Movement
+	for _, log := range receipt.Logs {
+		if log.Address != t.Address() {
+			continue
+		}
+		if m, err := ParseMovements(*log); err == nil {
+			movements = append(movements, m...)
+		}
+	}
+	return movements
+}
@@ -0,0 +1,240 @@
+package erc1155
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/internal/ethtest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testContractAddress = common.HexToAddress("0x76BE3b62873462d2142405439777e971754E8E77")
+
+	errReverted = errors.New("execution reverted")
+)
+
+type balanceKey struct {
+	id      uint64
+	account common.Address
+}
+
+type multiTokenState struct {
+	balances  map[balanceKey]*big.Int
+	operators map[[2]common.Address]bool
+}
+
+func newMultiTokenState() *multiTokenState {
+	return &multiTokenState{
+		balances:  make(map[balanceKey]*big.Int),
+		operators: make(map[[2]common.Address]bool),
+	}
+}
+
+func (s *multiTokenState) clone() *multiTokenState {
+	cp := newMultiTokenState()
+	for k, v := range s.balances {
+		cp.balances[k] = new(big.Int).Set(v)
+	}
+	for k, v := range s.operators {
+		cp.operators[k] = v
+	}
+	return cp
+}
+
+func (s *multiTokenState) balance(account common.Address, id *big.Int) *big.Int {
+	if b, ok := s.balances[balanceKey{id.Uint64(), account}]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+// fakeMultiTokenChain is an in-process node running a single ERC1155
+// contract.
+type fakeMultiTokenChain struct {
+	*ethtest.Chain
+	abi   *abi.ABI
+	state *multiTokenState
+}
+
+func newFakeMultiTokenChain(t *testing.T) *fakeMultiTokenChain {
+	parsed, err := IERC1155MetaData.GetAbi()
+	require.NoError(t, err)
+	c := &fakeMultiTokenChain{abi: parsed, state: newMultiTokenState()}
+	c.Chain = ethtest.NewChain(c)
+	return c
+}
+
+func (c *fakeMultiTokenChain) Snapshot() interface{} {
+	return c.state.clone()
+}
+
+func (c *fakeMultiTokenChain) Revert(snapshot interface{}) {
+	c.state = snapshot.(*multiTokenState)
+}
+
+// Run executes a contract call of sender and returns its return data and
+// events.
+func (c *fakeMultiTokenChain) Run(sender common.Address, input []byte) ([]byte, []*types.Log, error) {
+	method, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+
+	transfer := func(from, to common.Address, ids, amounts []*big.Int) error {
+		if sender != from && !c.state.operators[[2]common.Address{from, sender}] {
+			return errReverted
+		}
+		for i, id := range ids {
+			if c.state.balance(from, id).Cmp(amounts[i]) < 0 {
+				return errReverted
+			}
+		}
+		for i, id := range ids {
+			c.state.balances[balanceKey{id.Uint64(), from}] = new(big.Int).Sub(c.state.balance(from, id), amounts[i])
+			c.state.balances[balanceKey{id.Uint64(), to}] = new(big.Int).Add(c.state.balance(to, id), amounts[i])
+		}
+		return nil
+	}
+	topics := func(event string, a, b, d common.Address) []common.Hash {
+		return []common.Hash{c.abi.Events[event].ID, common.BytesToHash(a[:]), common.BytesToHash(b[:]), common.BytesToHash(d[:])}
+	}
+
+	switch method.Name {
+	case "balanceOf":
+		ret, _ := method.Outputs.Pack(c.state.balance(values[0].(common.Address), values[1].(*big.Int)))
+		return ret, nil, nil
+	case "balanceOfBatch":
+		accounts, ids := values[0].([]common.Address), values[1].([]*big.Int)
+		balances := make([]*big.Int, len(accounts))
+		for i := range accounts {
+			balances[i] = c.state.balance(accounts[i], ids[i])
+		}
+		ret, _ := method.Outputs.Pack(balances)
+		return ret, nil, nil
+	case "isApprovedForAll":
+		ret, _ := method.Outputs.Pack(c.state.operators[[2]common.Address{values[0].(common.Address), values[1].(common.Address)}])
+		return ret, nil, nil
+	case "uri":
+		ret, _ := method.Outputs.Pack("https://token-cdn-domain/{id}.json")
+		return ret, nil, nil
+	case "setApprovalForAll":
+		operator, approved := values[0].(common.Address), values[1].(bool)
+		c.state.operators[[2]common.Address{sender, operator}] = approved
+		return nil, nil, nil
+	case "safeTransferFrom":
+		from, to, id, value := values[0].(common.Address), values[1].(common.Address), values[2].(*big.Int), values[3].(*big.Int)
+		if err := transfer(from, to, []*big.Int{id}, []*big.Int{value}); err != nil {
+			return nil, nil, err
+		}
+		data, _ := c.abi.Events["TransferSingle"].Inputs.NonIndexed().Pack(id, value)
+		return nil, []*types.Log{{Address: testContractAddress, Topics: topics("TransferSingle", sender, from, to), Data: data, BlockNumber: 1}}, nil
+	case "safeBatchTransferFrom":
+		from, to, ids, amounts := values[0].(common.Address), values[1].(common.Address), values[2].([]*big.Int), values[3].([]*big.Int)
+		if len(ids) != len(amounts) {
+			return nil, nil, errReverted
+		}
+		if err := transfer(from, to, ids, amounts); err != nil {
+			return nil, nil, err
+		}
+		data, _ := c.abi.Events["TransferBatch"].Inputs.NonIndexed().Pack(ids, amounts)
+		return nil, []*types.Log{{Address: testContractAddress, Topics: topics("TransferBatch", sender, from, to), Data: data, BlockNumber: 1}}, nil
+	}
+	return nil, nil, errReverted
+}
+
+func newTestToken(t *testing.T, chain *fakeMultiTokenChain) *Token {
+	client := ethtest.Dial(t, chain.Chain)
+	token, err := NewToken(testContractAddress, ethtest.ChainID, client, ethereum.NewTransactor(client, ethtest.ChainID, nil))
+	require.NoError(t, err)
+	token.SetReceiptPollInterval(10 * time.Millisecond)
+	return token
+}
+
+func TestToken(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeMultiTokenChain(t)
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	operator := ethereum.NewPrivateKeySigner(operatorKey)
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	chain.state.balances[balanceKey{1, owner.Address()}] = big.NewInt(100)
+	chain.state.balances[balanceKey{2, owner.Address()}] = big.NewInt(5)
+
+	balance, err := token.BalanceOf(ctx, owner.Address(), big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	uri, err := token.URI(ctx, big.NewInt(314592))
+	require.NoError(t, err)
+	require.Equal(t, "https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json", uri)
+
+	hash, err := token.SafeTransferFrom(ctx, owner, owner.Address(), recipient, big.NewInt(1), big.NewInt(30), nil)
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, []Movement{{
+		Contract: testContractAddress,
+		Operator: owner.Address(),
+		From:     owner.Address(),
+		To:       recipient,
+		ID:       big.NewInt(1),
+		Value:    big.NewInt(30),
+		TxHash:   receipt.TxHash,
+	}}, token.Movements(receipt))
+
+	// batch transfer by an operator
+	hash, err = token.SetApprovalForAll(ctx, owner, operator.Address(), true)
+	require.NoError(t, err)
+	_, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	approved, err := token.IsApprovedForAll(ctx, owner.Address(), operator.Address())
+	require.NoError(t, err)
+	require.True(t, approved)
+
+	hash, err = token.SafeBatchTransferFrom(ctx, operator, owner.Address(), recipient, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(20), big.NewInt(5)}, []byte("memo"))
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	movements := token.Movements(receipt)
+	require.Len(t, movements, 2)
+	for i, expected := range []struct{ id, value int64 }{{1, 20}, {2, 5}} {
+		require.Equal(t, operator.Address(), movements[i].Operator)
+		require.Equal(t, owner.Address(), movements[i].From)
+		require.Equal(t, recipient, movements[i].To)
+		require.Equal(t, big.NewInt(expected.id), movements[i].ID)
+		require.Equal(t, big.NewInt(expected.value), movements[i].Value)
+	}
+
+	balances, err := token.BalanceOfBatch(ctx,
+		[]common.Address{owner.Address(), recipient, owner.Address(), recipient},
+		[]*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(2), big.NewInt(2)})
+	require.NoError(t, err)
+	require.Len(t, balances, 4)
+	for i, expected := range []int64{50, 50, 0, 5} {
+		require.Zero(t, balances[i].Cmp(big.NewInt(expected)), i)
+	}
+
+	_, err = token.BalanceOfBatch(ctx, []common.Address{owner.Address()}, nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = token.SafeBatchTransferFromArgs(owner.Address(), owner.Address(), recipient, []*big.Int{big.NewInt(1)}, nil, nil)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
@@ -0,0 +1,55 @@
+package erc20
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidAmount = errors.New("invalid token amount")
+
+// ParseAmount converts a human-readable decimal amount such as "1.5" into base
+// units of a token with decimals. Amounts more precise than the token are
+// rejected rather than rounded.
+func ParseAmount(s string, decimals uint8) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return nil, errors.Wrapf(ErrInvalidAmount, "%q", s)
+	}
+	if len(frac) > int(decimals) {
+		return nil, errors.Wrapf(ErrInvalidAmount, "%q has more than %d decimals", s, decimals)
+	}
+
+	amount, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", int(decimals)-len(frac)), 10)
+	return amount, nil
+}
+
+// FormatAmount renders base units of a token with decimals as a decimal
+// string without trailing zeros.
+func FormatAmount(amount *big.Int, decimals uint8) string {
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	s := whole
+	if frac != "" {
+		s += "." + frac
+	}
+	if amount.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,34 +3,52 @@ package erc20
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	"github.com/pkg/errors"
 )
 
 const (
+	ERC20ContractName = "ERC20"
+
 	USDT_CONTRACT_ADDRESS = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
-	USDT_CONTRACT_NAME    = "ERC20"
+	USDT_CONTRACT_NAME    = ERC20ContractName
 	USDT_TOKEN_NAME       = "USDT"
 )
 
 var (
-	UsdtContract *ERC20Contract
+	// UsdtContract is USDT on Ethereum mainnet.
+	// Deprecated: look tokens up in DefaultTokenRegistry, which covers every chain.
+	UsdtContract = DefaultTokenRegistry.MustGet(wallet_common.ChainID(wallet_common.EthereumMainnet), common.HexToAddress(USDT_CONTRACT_ADDRESS))
 )
 
-func init() {
-	UsdtContract = &ERC20Contract{
-		Name:      USDT_CONTRACT_NAME,
-		TokenName: USDT_TOKEN_NAME,
-		Address:   USDT_CONTRACT_ADDRESS,
-		Abi:       IERC20MetaData.ABI,
-	}
-}
-
 type ERC20Contract struct {
-	Address   string
-	Name      string
+	ChainID wallet_common.ChainID
+	Address string
+	Name    string
+	// TokenName is the symbol of the token, e.g. USDT.
 	TokenName string
+	FullName  string
+	Decimals  uint8
 	Abi       string
 }
 
+func (e *ERC20Contract) Validate() error {
+	if e.ChainID == wallet_common.ChainID(wallet_common.UnknownChainID) {
+		return errors.Wrap(ErrInvalidToken, "chainId is required")
+	}
+	if !common.IsHexAddress(e.Address) {
+		return errors.Wrapf(ErrInvalidToken, "chain %d: invalid address %q", e.ChainID, e.Address)
+	}
+	if e.TokenName == "" {
+		return errors.Wrapf(ErrInvalidToken, "chain %d: %s: symbol is required", e.ChainID, e.Address)
+	}
+	return nil
+}
+
+func (e *ERC20Contract) GetChainID() wallet_common.ChainID {
+	return e.ChainID
+}
+
 func (e *ERC20Contract) GetContractAddress() string {
 	return e.Address
 }
@@ -47,6 +65,21 @@ func (e *ERC20Contract) GetTokenName() string {
 	return e.TokenName
 }
 
+func (e *ERC20Contract) GetDecimals() uint8 {
+	return e.Decimals
+}
+
+// Metadata returns the metadata of the registry entry, e.g. to seed a
+// MetadataResolver.
+func (e *ERC20Contract) Metadata() *Metadata {
+	return &Metadata{
+		Address:  common.HexToAddress(e.Address),
+		Name:     e.FullName,
+		Symbol:   e.TokenName,
+		Decimals: e.Decimals,
+	}
+}
+
 func (e *ERC20Contract) ParseTransfer(log *types.Log) (*IERC20Transfer, error) {
 	filter, err := NewIERC20Filterer(common.HexToAddress(e.GetContractAddress()), nil)
 	if err != nil {
@@ -0,0 +1,185 @@
+package erc20
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"unicode/utf8"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+)
+
+const bytes32StringLength = 32
+
+var (
+	ErrNoDecimals      = errors.New("token doesn't implement decimals()")
+	ErrInvalidMetadata = errors.New("invalid token metadata")
+)
+
+var metadataABI = mustParseABI(IERC20MetadataMetaData)
+
+func mustParseABI(metadata *bind.MetaData) *abi.ABI {
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Metadata holds the optional IERC20Metadata fields of a token. Name and
+// Symbol are empty for tokens that don't implement them.
+type Metadata struct {
+	Address  common.Address
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// ParseAmount converts a decimal amount into base units of the token.
+func (m *Metadata) ParseAmount(s string) (*big.Int, error) {
+	return ParseAmount(s, m.Decimals)
+}
+
+// FormatAmount renders base units of the token as a decimal amount.
+func (m *Metadata) FormatAmount(amount *big.Int) string {
+	return FormatAmount(amount, m.Decimals)
+}
+
+// MetadataResolver reads and caches token metadata of one chain. Tokens that
+// return bytes32 instead of string for name and symbol, like MKR, are supported.
+type MetadataResolver struct {
+	client *ethclient.Client
+
+	mu    sync.Mutex
+	cache map[common.Address]*Metadata
+}
+
+func NewMetadataResolver(client *ethclient.Client) *MetadataResolver {
+	return &MetadataResolver{
+		client: client,
+		cache:  make(map[common.Address]*Metadata),
+	}
+}
+
+// Resolve returns the metadata of token, reading it from the chain on first use.
+func (r *MetadataResolver) Resolve(ctx context.Context, token common.Address) (*Metadata, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[token]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	decimals, err := r.decimals(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	name, err := r.text(ctx, token, "name")
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := r.text(ctx, token, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{Address: token, Name: name, Symbol: symbol, Decimals: decimals}
+
+	r.mu.Lock()
+	r.cache[token] = metadata
+	r.mu.Unlock()
+	return metadata, nil
+}
+
+// Set records metadata known out of band, e.g. from a token list.
+func (r *MetadataResolver) Set(metadata *Metadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache[metadata.Address] = metadata
+}
+
+// NewToken returns a client of token with its resolved decimals and symbol.
+func (r *MetadataResolver) NewToken(ctx context.Context, token common.Address, chainID *big.Int, transactor *ethereum.Transactor) (*Token, error) {
+	metadata, err := r.Resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewToken(token, metadata.Decimals, chainID, r.client, transactor)
+	if err != nil {
+		return nil, err
+	}
+	t.SetSymbol(metadata.Symbol)
+	return t, nil
+}
+
+func (r *MetadataResolver) call(ctx context.Context, token common.Address, method string) ([]byte, error) {
+	data, err := metadataABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.CallContract(ctx, goethereum.CallMsg{To: &token, Data: data}, nil)
+}
+
+func (r *MetadataResolver) decimals(ctx context.Context, token common.Address) (uint8, error) {
+	result, err := r.call(ctx, token, "decimals")
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return 0, errors.Wrap(ErrNoDecimals, token.Hex())
+		}
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, errors.Wrap(ErrNoDecimals, token.Hex())
+	}
+	// some tokens declare decimals() as uint256
+	if len(result) != 32 {
+		return 0, errors.Wrapf(ErrInvalidMetadata, "%s: decimals() returned %d bytes", token.Hex(), len(result))
+	}
+	decimals := new(big.Int).SetBytes(result)
+	if !decimals.IsUint64() || decimals.Uint64() > 255 {
+		return 0, errors.Wrapf(ErrInvalidMetadata, "%s: decimals() returned %s", token.Hex(), decimals)
+	}
+	return uint8(decimals.Uint64()), nil
+}
+
+// text reads name() or symbol(), which are optional in ERC20 and empty when
+// missing.
+func (r *MetadataResolver) text(ctx context.Context, token common.Address, method string) (string, error) {
+	result, err := r.call(ctx, token, method)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	s, err := decodeString(result)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidMetadata, "%s: %s(): %s", token.Hex(), method, err)
+	}
+	return s, nil
+}
+
+// decodeString decodes an ABI string, or a bytes32 padded with zeros.
+func decodeString(result []byte) (string, error) {
+	switch {
+	case len(result) == 0:
+		return "", nil
+	case len(result) == bytes32StringLength:
+		s := string(bytes.TrimRight(result, "\x00"))
+		if !utf8.ValidString(s) {
+			return "", errors.New("bytes32 isn't valid UTF-8")
+		}
+		return s, nil
+	}
+	values, err := metadataABI.Methods["name"].Outputs.Unpack(result)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
@@ -0,0 +1,148 @@
+package erc20
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	usdcAddress     = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	mkrAddress      = common.HexToAddress("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2")
+	anonymousToken  = common.HexToAddress("0x0000000000000000000000000000000000001111")
+	uint256Decimals = common.HexToAddress("0x0000000000000000000000000000000000002222")
+	notAToken       = common.HexToAddress("0x0000000000000000000000000000000000003333")
+	hugeDecimals    = common.HexToAddress("0x0000000000000000000000000000000000004444")
+)
+
+// fakeMetadataAPI answers name, symbol and decimals calls of a few tokens
+// with the encodings found on mainnet.
+type fakeMetadataAPI struct {
+	calls atomic.Int32
+	// failing names a method whose calls fail the way an overloaded node fails
+	failing atomic.Value
+}
+
+func (api *fakeMetadataAPI) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	api.calls.Add(1)
+	to := common.HexToAddress(args["to"].(string))
+	method, err := metadataABI.MethodById(hexutil.MustDecode(args["input"].(string)))
+	if err != nil {
+		return nil, err
+	}
+	if failing, _ := api.failing.Load().(string); failing == method.Name {
+		return nil, errors.New("upstream timeout")
+	}
+
+	word := func(v int64) hexutil.Bytes { return common.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+	bytes32 := func(s string) hexutil.Bytes { return common.RightPadBytes([]byte(s), 32) }
+	str := func(s string) hexutil.Bytes {
+		packed, _ := method.Outputs.Pack(s)
+		return packed
+	}
+
+	switch to {
+	case usdcAddress:
+		return map[string]hexutil.Bytes{"name": str("USD Coin"), "symbol": str("USDC"), "decimals": word(6)}[method.Name], nil
+	case mkrAddress:
+		return map[string]hexutil.Bytes{"name": bytes32("Maker"), "symbol": bytes32("MKR"), "decimals": word(18)}[method.Name], nil
+	case anonymousToken:
+		if method.Name == "decimals" {
+			return word(8), nil
+		}
+		return nil, errors.New("execution reverted")
+	case uint256Decimals:
+		return map[string]hexutil.Bytes{"name": str("Old"), "symbol": str("OLD"), "decimals": word(2)}[method.Name], nil
+	case hugeDecimals:
+		return word(256), nil
+	}
+	// calls to accounts without code succeed with no output
+	return nil, nil
+}
+
+func newTestResolver(t *testing.T) (*MetadataResolver, *fakeMetadataAPI) {
+	api := &fakeMetadataAPI{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	t.Cleanup(server.Stop)
+	return NewMetadataResolver(ethclient.NewClient(rpc.DialInProc(server))), api
+}
+
+func TestMetadataResolver(t *testing.T) {
+	ctx := context.Background()
+	resolver, api := newTestResolver(t)
+
+	testCases := []struct {
+		token    common.Address
+		expected Metadata
+	}{
+		{usdcAddress, Metadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6}},
+		{mkrAddress, Metadata{Name: "Maker", Symbol: "MKR", Decimals: 18}},
+		{anonymousToken, Metadata{Decimals: 8}},
+		{uint256Decimals, Metadata{Name: "Old", Symbol: "OLD", Decimals: 2}},
+	}
+	for _, tc := range testCases {
+		metadata, err := resolver.Resolve(ctx, tc.token)
+		require.NoError(t, err)
+		tc.expected.Address = tc.token
+		require.Equal(t, tc.expected, *metadata)
+	}
+
+	// cached
+	calls := api.calls.Load()
+	metadata, err := resolver.Resolve(ctx, usdcAddress)
+	require.NoError(t, err)
+	require.Equal(t, calls, api.calls.Load())
+	require.Equal(t, "1234.5", metadata.FormatAmount(big.NewInt(1_234_500_000)))
+
+	_, err = resolver.Resolve(ctx, notAToken)
+	require.ErrorIs(t, err, ErrNoDecimals)
+	_, err = resolver.Resolve(ctx, hugeDecimals)
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+
+	resolver.Set(&Metadata{Address: notAToken, Symbol: "LIST", Decimals: 4})
+	token, err := resolver.NewToken(ctx, notAToken, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint8(4), token.Decimals())
+	require.Equal(t, "LIST", token.Symbol())
+	args, err := token.TransferArgs(common.Address{1}, common.Address{2}, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, "LIST", args.Symbol)
+}
+
+func TestMetadataResolverNodeErrors(t *testing.T) {
+	ctx := context.Background()
+	resolver, api := newTestResolver(t)
+
+	// a failing node is neither a missing method nor cached
+	for _, method := range []string{"decimals", "name", "symbol"} {
+		api.failing.Store(method)
+		_, err := resolver.Resolve(ctx, usdcAddress)
+		require.Error(t, err, method)
+		require.NotErrorIs(t, err, ErrNoDecimals, method)
+	}
+
+	api.failing.Store("")
+	metadata, err := resolver.Resolve(ctx, usdcAddress)
+	require.NoError(t, err)
+	require.Equal(t, Metadata{Address: usdcAddress, Name: "USD Coin", Symbol: "USDC", Decimals: 6}, *metadata)
+}
+
+func TestDecodeString(t *testing.T) {
+	s, err := decodeString(common.RightPadBytes([]byte("DAI"), 32))
+	require.NoError(t, err)
+	require.Equal(t, "DAI", s)
+
+	_, err = decodeString(common.RightPadBytes([]byte{0xff, 0xfe}, 32))
+	require.Error(t, err)
+	_, err = decodeString([]byte{1, 2, 3})
+	require.Error(t, err)
+}
@@ -0,0 +1,392 @@
+package erc20
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TransferWithAuthorization authorizations can be submitted by anyone.
+	TransferWithAuthorization = "TransferWithAuthorization"
+	// ReceiveWithAuthorization authorizations can only be submitted by the
+	// payee, which keeps them from being front-run.
+	ReceiveWithAuthorization = "ReceiveWithAuthorization"
+)
+
+var (
+	ErrPermitNotSupported = errors.New("token doesn't support signed approvals")
+	ErrDomainMismatch     = errors.New("EIP-712 domain doesn't match the token")
+	ErrInvalidRelayer     = errors.New("relayer can't submit the authorization")
+
+	permitABI = mustParseABI(IERC20PermitTokenMetaData)
+
+	permitTypes = []eip712.Type{
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	}
+	authorizationTypes = []eip712.Type{
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "validAfter", Type: "uint256"},
+		{Name: "validBefore", Type: "uint256"},
+		{Name: "nonce", Type: "bytes32"},
+	}
+)
+
+// EIP-5267 flags of the domain fields in use
+const (
+	domainFieldName = 1 << iota
+	domainFieldVersion
+	domainFieldChainID
+	domainFieldVerifyingContract
+	domainFieldSalt
+)
+
+// Permit is an ERC-2612 approval of spender for value, valid until Deadline.
+type Permit struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+}
+
+func (p *Permit) TypedData(domain eip712.Domain) *eip712.TypedData {
+	return typedData(domain, "Permit", permitTypes, eip712.Message{
+		"owner":    p.Owner.Hex(),
+		"spender":  p.Spender.Hex(),
+		"value":    p.Value.String(),
+		"nonce":    p.Nonce.String(),
+		"deadline": p.Deadline.String(),
+	})
+}
+
+type SignedPermit struct {
+	Permit
+	Domain    eip712.Domain
+	Signature []byte
+}
+
+// Authorization is an EIP-3009 transfer of Value from From to To, valid
+// strictly between ValidAfter and ValidBefore. Type is TransferWithAuthorization
+// or ReceiveWithAuthorization.
+type Authorization struct {
+	Type        string
+	From        common.Address
+	To          common.Address
+	Value       *big.Int
+	ValidAfter  *big.Int
+	ValidBefore *big.Int
+	Nonce       common.Hash
+}
+
+func (a *Authorization) TypedData(domain eip712.Domain) *eip712.TypedData {
+	return typedData(domain, a.Type, authorizationTypes, eip712.Message{
+		"from":        a.From.Hex(),
+		"to":          a.To.Hex(),
+		"value":       a.Value.String(),
+		"validAfter":  a.ValidAfter.String(),
+		"validBefore": a.ValidBefore.String(),
+		"nonce":       a.Nonce.Hex(),
+	})
+}
+
+type SignedAuthorization struct {
+	Authorization
+	Domain    eip712.Domain
+	Signature []byte
+}
+
+func typedData(domain eip712.Domain, primaryType string, fields []eip712.Type, message eip712.Message) *eip712.TypedData {
+	return &eip712.TypedData{
+		Types: eip712.Types{
+			eip712.DomainType: eip712.DomainTypes(domain),
+			primaryType:       fields,
+		},
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	}
+}
+
+// NewAuthorizationNonce returns a random EIP-3009 nonce. Unlike ERC-2612
+// nonces they aren't sequential, so authorizations can be used in any order.
+func NewAuthorizationNonce() (common.Hash, error) {
+	var nonce common.Hash
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return common.Hash{}, err
+	}
+	return nonce, nil
+}
+
+// Domain returns the EIP-712 domain the token verifies signed approvals
+// against. It is read with EIP-5267 when the token implements it. Otherwise it
+// is rebuilt from name() and version(), defaulting to "1", and checked against
+// DOMAIN_SEPARATOR().
+func (t *Token) Domain(ctx context.Context) (eip712.Domain, error) {
+	result, err := t.permitCall(ctx, "eip712Domain")
+	if err != nil && !ethereum.IsRevert(err) {
+		return eip712.Domain{}, err
+	}
+	if err == nil && len(result) > 0 {
+		return t.eip5267Domain(result)
+	}
+
+	result, err = t.permitCall(ctx, "DOMAIN_SEPARATOR")
+	if err != nil && !ethereum.IsRevert(err) {
+		return eip712.Domain{}, err
+	}
+	if err != nil || len(result) != 32 {
+		return eip712.Domain{}, errors.Wrapf(ErrPermitNotSupported, "%s has no DOMAIN_SEPARATOR()", t.Address().Hex())
+	}
+	separator := common.BytesToHash(result)
+
+	name, err := t.rawCall(ctx, metadataABI, "name")
+	if err != nil {
+		return eip712.Domain{}, errors.Wrapf(err, "%s: name()", t.Address().Hex())
+	}
+	domain := eip712.Domain{
+		Version:           "1",
+		ChainId:           (*math.HexOrDecimal256)(t.ChainID()),
+		VerifyingContract: t.Address().Hex(),
+	}
+	if domain.Name, err = decodeString(name); err != nil {
+		return eip712.Domain{}, errors.Wrapf(ErrInvalidMetadata, "%s: name(): %s", t.Address().Hex(), err)
+	}
+	version, err := t.permitCall(ctx, "version")
+	switch {
+	case err != nil && !ethereum.IsRevert(err):
+		return eip712.Domain{}, err
+	case err == nil && len(version) > 0:
+		if domain.Version, err = decodeString(version); err != nil {
+			return eip712.Domain{}, errors.Wrapf(ErrInvalidMetadata, "%s: version(): %s", t.Address().Hex(), err)
+		}
+	}
+
+	expected, err := eip712.DomainSeparator(&eip712.TypedData{
+		Types:  eip712.Types{eip712.DomainType: eip712.DomainTypes(domain)},
+		Domain: domain,
+	})
+	if err != nil {
+		return eip712.Domain{}, err
+	}
+	if expected != separator {
+		return eip712.Domain{}, errors.Wrapf(ErrDomainMismatch, "%s: DOMAIN_SEPARATOR() is %s, computed %s for %q version %q", t.Address().Hex(), separator.Hex(), expected.Hex(), domain.Name, domain.Version)
+	}
+	return domain, nil
+}
+
+func (t *Token) eip5267Domain(result []byte) (eip712.Domain, error) {
+	values, err := permitABI.Methods["eip712Domain"].Outputs.Unpack(result)
+	if err != nil {
+		return eip712.Domain{}, errors.Wrapf(ErrInvalidMetadata, "%s: eip712Domain(): %s", t.Address().Hex(), err)
+	}
+	fields := values[0].([1]byte)[0]
+	if extensions := values[6].([]*big.Int); len(extensions) > 0 {
+		return eip712.Domain{}, errors.Wrapf(ErrPermitNotSupported, "%s uses EIP-712 domain extensions", t.Address().Hex())
+	}
+
+	var domain eip712.Domain
+	if fields&domainFieldName != 0 {
+		domain.Name = values[1].(string)
+	}
+	if fields&domainFieldVersion != 0 {
+		domain.Version = values[2].(string)
+	}
+	if fields&domainFieldChainID != 0 {
+		chainID := values[3].(*big.Int)
+		if chainID.Cmp(t.ChainID()) != 0 {
+			return eip712.Domain{}, errors.Wrapf(ErrDomainMismatch, "%s: chain id %s, expected %s", t.Address().Hex(), chainID, t.ChainID())
+		}
+		domain.ChainId = (*math.HexOrDecimal256)(chainID)
+	}
+	if fields&domainFieldVerifyingContract != 0 {
+		domain.VerifyingContract = values[4].(common.Address).Hex()
+	}
+	if fields&domainFieldSalt != 0 {
+		salt := values[5].([32]byte)
+		domain.Salt = hexutil.Encode(salt[:])
+	}
+	return domain, nil
+}
+
+// Nonces returns the next ERC-2612 nonce of owner.
+func (t *Token) Nonces(ctx context.Context, owner common.Address) (*big.Int, error) {
+	result, err := t.permitCall(ctx, "nonces", owner)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return nil, errors.Wrapf(ErrPermitNotSupported, "%s has no nonces()", t.Address().Hex())
+		}
+		return nil, err
+	}
+	if len(result) != 32 {
+		return nil, errors.Wrapf(ErrPermitNotSupported, "%s: nonces() returned %d bytes", t.Address().Hex(), len(result))
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// AuthorizationState reports whether the EIP-3009 authorization nonce of
+// authorizer was used or canceled.
+func (t *Token) AuthorizationState(ctx context.Context, authorizer common.Address, nonce common.Hash) (bool, error) {
+	result, err := t.permitCall(ctx, "authorizationState", authorizer, nonce)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return false, errors.Wrapf(ErrPermitNotSupported, "%s has no authorizationState()", t.Address().Hex())
+		}
+		return false, err
+	}
+	values, err := permitABI.Methods["authorizationState"].Outputs.Unpack(result)
+	if err != nil {
+		return false, errors.Wrapf(ErrPermitNotSupported, "%s: authorizationState(): %s", t.Address().Hex(), err)
+	}
+	return values[0].(bool), nil
+}
+
+func (t *Token) permitCall(ctx context.Context, method string, args ...interface{}) ([]byte, error) {
+	return t.rawCall(ctx, permitABI, method, args...)
+}
+
+func (t *Token) rawCall(ctx context.Context, parsed *abi.ABI, method string, args ...interface{}) ([]byte, error) {
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	to := t.Address()
+	return t.Client().CallContract(ctx, goethereum.CallMsg{To: &to, Data: data}, nil)
+}
+
+// SignPermit signs an ERC-2612 approval of spender for value by signer, using
+// the token's current domain and signer's next nonce.
+func (t *Token) SignPermit(ctx context.Context, signer ethereum.Signer, spender common.Address, value *big.Int, deadline *big.Int) (*SignedPermit, error) {
+	domain, err := t.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := t.Nonces(ctx, signer.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	permit := Permit{
+		Owner:    signer.Address(),
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}
+	sig, err := signer.SignTypedData(*permit.TypedData(domain))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedPermit{Permit: permit, Domain: domain, Signature: sig}, nil
+}
+
+// PermitArgs returns the arguments of the permit call submitting permit,
+// sent and paid for by relayer.
+func (t *Token) PermitArgs(relayer common.Address, permit *SignedPermit) (_types.SendTxArgs, error) {
+	v, r, s, err := splitSignature(permit.Signature)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	data, err := permitABI.Pack("permit", permit.Owner, permit.Spender, permit.Value, permit.Deadline, v, r, s)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	return t.TxArgs(relayer, data), nil
+}
+
+// SignTransferAuthorization signs an EIP-3009 transfer of value from signer to
+// to, which any relayer can submit between validAfter and validBefore.
+func (t *Token) SignTransferAuthorization(ctx context.Context, signer ethereum.Signer, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int) (*SignedAuthorization, error) {
+	return t.signAuthorization(ctx, signer, TransferWithAuthorization, to, value, validAfter, validBefore)
+}
+
+// SignReceiveAuthorization signs an EIP-3009 transfer of value from signer to
+// to, which only to can submit.
+func (t *Token) SignReceiveAuthorization(ctx context.Context, signer ethereum.Signer, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int) (*SignedAuthorization, error) {
+	return t.signAuthorization(ctx, signer, ReceiveWithAuthorization, to, value, validAfter, validBefore)
+}
+
+func (t *Token) signAuthorization(ctx context.Context, signer ethereum.Signer, typ string, to common.Address, value *big.Int, validAfter *big.Int, validBefore *big.Int) (*SignedAuthorization, error) {
+	domain, err := t.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := NewAuthorizationNonce()
+	if err != nil {
+		return nil, err
+	}
+	if validAfter == nil {
+		validAfter = new(big.Int)
+	}
+
+	authorization := Authorization{
+		Type:        typ,
+		From:        signer.Address(),
+		To:          to,
+		Value:       value,
+		ValidAfter:  validAfter,
+		ValidBefore: validBefore,
+		Nonce:       nonce,
+	}
+	sig, err := signer.SignTypedData(*authorization.TypedData(domain))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedAuthorization{Authorization: authorization, Domain: domain, Signature: sig}, nil
+}
+
+// AuthorizationArgs returns the arguments of the transferWithAuthorization or
+// receiveWithAuthorization call submitting authorization, sent by relayer.
+func (t *Token) AuthorizationArgs(relayer common.Address, authorization *SignedAuthorization) (_types.SendTxArgs, error) {
+	var method string
+	switch authorization.Type {
+	case TransferWithAuthorization:
+		method = "transferWithAuthorization"
+	case ReceiveWithAuthorization:
+		if relayer != authorization.To {
+			return _types.SendTxArgs{}, errors.Wrapf(ErrInvalidRelayer, "%s must be submitted by %s", authorization.Type, authorization.To.Hex())
+		}
+		method = "receiveWithAuthorization"
+	default:
+		return _types.SendTxArgs{}, errors.Errorf("unknown authorization type %q", authorization.Type)
+	}
+
+	v, r, s, err := splitSignature(authorization.Signature)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	data, err := permitABI.Pack(method, authorization.From, authorization.To, authorization.Value,
+		authorization.ValidAfter, authorization.ValidBefore, [32]byte(authorization.Nonce), v, r, s)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	return t.TxArgs(relayer, data), nil
+}
+
+// splitSignature returns the v, r and s arguments of sig, with v 27 or 28.
+func splitSignature(sig []byte) (uint8, [32]byte, [32]byte, error) {
+	var r, s [32]byte
+	sig, err := ethereum.NormalizeSignature(sig, nil)
+	if err != nil {
+		return 0, r, s, err
+	}
+	copy(r[:], sig[:32])
+	copy(s[:], sig[32:64])
+	return sig[64] + 27, r, s, nil
+}
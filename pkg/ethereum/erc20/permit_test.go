@@ -0,0 +1,274 @@
+package erc20
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/openweb3-io/anychain/pkg/ethereum/internal/ethtest"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sigverify"
+	"github.com/stretchr/testify/require"
+)
+
+type authorizationKey struct {
+	authorizer common.Address
+	nonce      common.Hash
+}
+
+type fakePermit struct {
+	domain eip712.Domain
+	// hasVersion exposes version(), which tokens with version "1" may omit
+	hasVersion bool
+	eip5267    bool
+	// separator overrides DOMAIN_SEPARATOR() when set
+	separator common.Hash
+}
+
+// runPermit executes the ERC-2612, EIP-3009 and EIP-5267 calls of the token.
+func (c *fakeTokenChain) runPermit(state *tokenState, sender common.Address, input []byte) ([]byte, []*types.Log, error) {
+	method, err := permitABI.MethodById(input[:4])
+	if err != nil {
+		method, err = metadataABI.MethodById(input[:4])
+	}
+	if err != nil || c.permit == nil {
+		return nil, nil, errReverted
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+	domain := c.permit.domain
+
+	recover := func(typedData *eip712.TypedData, v uint8, r, s [32]byte) common.Address {
+		signer, err := sigverify.RecoverTypedData(typedData, append(append(r[:], s[:]...), v))
+		if err != nil {
+			return common.Address{}
+		}
+		return signer
+	}
+
+	switch method.Name {
+	case "name":
+		ret, _ := method.Outputs.Pack(domain.Name)
+		return ret, nil, nil
+	case "version":
+		if !c.permit.hasVersion {
+			return nil, nil, errReverted
+		}
+		ret, _ := method.Outputs.Pack(domain.Version)
+		return ret, nil, nil
+	case "eip712Domain":
+		if !c.permit.eip5267 {
+			return nil, nil, errReverted
+		}
+		ret, _ := method.Outputs.Pack([1]byte{0x0f}, domain.Name, domain.Version, (*big.Int)(domain.ChainId), common.HexToAddress(domain.VerifyingContract), [32]byte{}, []*big.Int{})
+		return ret, nil, nil
+	case "DOMAIN_SEPARATOR":
+		separator := c.permit.separator
+		if separator == (common.Hash{}) {
+			separator, _ = eip712.DomainSeparator(&eip712.TypedData{Types: eip712.Types{eip712.DomainType: eip712.DomainTypes(domain)}, Domain: domain})
+		}
+		return separator[:], nil, nil
+	case "nonces":
+		return common.BigToHash(new(big.Int).SetUint64(state.permitNonces[values[0].(common.Address)])).Bytes(), nil, nil
+	case "authorizationState":
+		ret, _ := method.Outputs.Pack(state.authorizations[authorizationKey{values[0].(common.Address), values[1].([32]byte)}])
+		return ret, nil, nil
+	case "permit":
+		permit := Permit{
+			Owner:    values[0].(common.Address),
+			Spender:  values[1].(common.Address),
+			Value:    values[2].(*big.Int),
+			Nonce:    new(big.Int).SetUint64(state.permitNonces[values[0].(common.Address)]),
+			Deadline: values[3].(*big.Int),
+		}
+		if recover(permit.TypedData(domain), values[4].(uint8), values[5].([32]byte), values[6].([32]byte)) != permit.Owner {
+			return nil, nil, errReverted
+		}
+		state.permitNonces[permit.Owner]++
+		state.allowances[[2]common.Address{permit.Owner, permit.Spender}] = permit.Value
+		return nil, []*types.Log{c.event("Approval", permit.Owner, permit.Spender, permit.Value)}, nil
+	case "transferWithAuthorization", "receiveWithAuthorization":
+		authorization := Authorization{
+			Type:        TransferWithAuthorization,
+			From:        values[0].(common.Address),
+			To:          values[1].(common.Address),
+			Value:       values[2].(*big.Int),
+			ValidAfter:  values[3].(*big.Int),
+			ValidBefore: values[4].(*big.Int),
+			Nonce:       values[5].([32]byte),
+		}
+		if method.Name == "receiveWithAuthorization" {
+			authorization.Type = ReceiveWithAuthorization
+			if sender != authorization.To {
+				return nil, nil, errReverted
+			}
+		}
+		key := authorizationKey{authorization.From, authorization.Nonce}
+		if state.authorizations[key] || recover(authorization.TypedData(domain), values[6].(uint8), values[7].([32]byte), values[8].([32]byte)) != authorization.From {
+			return nil, nil, errReverted
+		}
+		if state.balance(authorization.From).Cmp(authorization.Value) < 0 {
+			return nil, nil, errReverted
+		}
+		state.authorizations[key] = true
+		state.balances[authorization.From] = new(big.Int).Sub(state.balance(authorization.From), authorization.Value)
+		state.balances[authorization.To] = new(big.Int).Add(state.balance(authorization.To), authorization.Value)
+		return nil, []*types.Log{c.event("Transfer", authorization.From, authorization.To, authorization.Value)}, nil
+	}
+	return nil, nil, errReverted
+}
+
+func newTestDomain(name string, version string) eip712.Domain {
+	return eip712.Domain{
+		Name:              name,
+		Version:           version,
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: testTokenAddress.Hex(),
+	}
+}
+
+func TestDomain(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	token := newTestToken(t, chain)
+
+	_, err := token.Domain(ctx)
+	require.ErrorIs(t, err, ErrPermitNotSupported)
+
+	chain.permit = &fakePermit{domain: newTestDomain("USD Coin", "2"), hasVersion: true}
+	domain, err := token.Domain(ctx)
+	require.NoError(t, err)
+	require.Equal(t, chain.permit.domain, domain)
+
+	// version() is optional for version "1"
+	chain.permit = &fakePermit{domain: newTestDomain("Dai Stablecoin", "1")}
+	domain, err = token.Domain(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1", domain.Version)
+
+	chain.permit = &fakePermit{domain: newTestDomain("Uniswap", "2")}
+	_, err = token.Domain(ctx)
+	require.ErrorIs(t, err, ErrDomainMismatch)
+
+	chain.permit = &fakePermit{domain: newTestDomain("Token", "3"), eip5267: true, separator: common.HexToHash("0x01")}
+	domain, err = token.Domain(ctx)
+	require.NoError(t, err)
+	require.Equal(t, chain.permit.domain, domain)
+}
+
+func TestPermit(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	chain.permit = &fakePermit{domain: newTestDomain("USD Coin", "2"), hasVersion: true}
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethtest.TypedDataSigner{PrivateKeySigner: ethereum.NewPrivateKeySigner(ownerKey)}
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer := ethereum.NewPrivateKeySigner(relayerKey)
+	spender := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+
+	permit, err := token.SignPermit(ctx, owner, spender, big.NewInt(1_000_000), big.NewInt(1<<40))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), permit.Nonce.Int64())
+	require.Equal(t,
+		common.HexToHash("0x6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9"),
+		eip712.TypeHash(permit.TypedData(permit.Domain), "Permit"))
+
+	args, err := token.PermitArgs(relayer.Address(), permit)
+	require.NoError(t, err)
+	require.Equal(t, relayer.Address(), args.From)
+	hash, _, err := token.Send(ctx, relayer, args, -1)
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	allowance, err := token.Allowance(ctx, owner.Address(), spender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_000_000), allowance)
+	nonce, err := token.Nonces(ctx, owner.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), nonce.Int64())
+
+	// the nonce was used: the permit doesn't estimate, and reverts on chain
+	_, _, err = token.Send(ctx, relayer, args, -1)
+	require.Error(t, err)
+	gas := hexutil.Uint64(100_000)
+	args.Gas = &gas
+	hash, _, err = token.Send(ctx, relayer, args, -1)
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+}
+
+func TestAuthorization(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	chain.permit = &fakePermit{domain: newTestDomain("USD Coin", "2"), hasVersion: true}
+	token := newTestToken(t, chain)
+
+	payerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	payer := ethtest.TypedDataSigner{PrivateKeySigner: ethereum.NewPrivateKeySigner(payerKey)}
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer := ethereum.NewPrivateKeySigner(relayerKey)
+	payeeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	payee := ethereum.NewPrivateKeySigner(payeeKey)
+
+	chain.state.balances[payer.Address()] = big.NewInt(10_000_000)
+
+	authorization, err := token.SignTransferAuthorization(ctx, payer, payee.Address(), big.NewInt(3_000_000), nil, big.NewInt(1<<40))
+	require.NoError(t, err)
+	require.Equal(t,
+		common.HexToHash("0x7c7c6cdb67a18743f49ec6fa9b35f50d52ed05cbed4cc592e13b44501c1a2267"),
+		eip712.TypeHash(authorization.TypedData(authorization.Domain), TransferWithAuthorization))
+
+	args, err := token.AuthorizationArgs(relayer.Address(), authorization)
+	require.NoError(t, err)
+	hash, _, err := token.Send(ctx, relayer, args, -1)
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	_, err = token.VerifyTransfer(receipt, payer.Address(), payee.Address(), big.NewInt(3_000_000))
+	require.NoError(t, err)
+
+	used, err := token.AuthorizationState(ctx, payer.Address(), authorization.Nonce)
+	require.NoError(t, err)
+	require.True(t, used)
+
+	// receive authorizations are submitted by the payee
+	authorization, err = token.SignReceiveAuthorization(ctx, payer, payee.Address(), big.NewInt(1_000_000), nil, big.NewInt(1<<40))
+	require.NoError(t, err)
+	require.Equal(t,
+		common.HexToHash("0xd099cc98ef71107a616c4f0f941f04c322d8e254fe26b3c6668db87aae413de8"),
+		eip712.TypeHash(authorization.TypedData(authorization.Domain), ReceiveWithAuthorization))
+	_, err = token.AuthorizationArgs(relayer.Address(), authorization)
+	require.ErrorIs(t, err, ErrInvalidRelayer)
+
+	args, err = token.AuthorizationArgs(payee.Address(), authorization)
+	require.NoError(t, err)
+	hash, _, err = token.Send(ctx, payee, args, -1)
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	_, err = token.VerifyTransfer(receipt, payer.Address(), payee.Address(), big.NewInt(1_000_000))
+	require.NoError(t, err)
+
+	balance, err := token.BalanceOf(ctx, payee.Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4_000_000), balance)
+}
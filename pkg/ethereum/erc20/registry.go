@@ -0,0 +1,245 @@
+package erc20
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	"github.com/pkg/errors"
+)
+
+//go:embed tokens.json
+var defaultTokensJSON []byte
+
+var (
+	ErrUnknownToken  = errors.New("unknown token")
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrTokenMismatch = errors.New("token list doesn't match the chain")
+)
+
+// TokenList is a token list in the Uniswap format
+// (https://github.com/Uniswap/token-lists).
+type TokenList struct {
+	Name      string           `json:"name"`
+	Timestamp string           `json:"timestamp"`
+	Version   TokenListVersion `json:"version"`
+	Keywords  []string         `json:"keywords,omitempty"`
+	Tokens    []TokenInfo      `json:"tokens"`
+}
+
+type TokenListVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+type TokenInfo struct {
+	ChainID  wallet_common.ChainID `json:"chainId"`
+	Address  string                `json:"address"`
+	Name     string                `json:"name"`
+	Symbol   string                `json:"symbol"`
+	Decimals uint8                 `json:"decimals"`
+	LogoURI  string                `json:"logoURI,omitempty"`
+	Tags     []string              `json:"tags,omitempty"`
+}
+
+// Contract returns the registry entry of the token.
+func (t *TokenInfo) Contract() ERC20Contract {
+	return ERC20Contract{
+		ChainID:   t.ChainID,
+		Address:   t.Address,
+		Name:      ERC20ContractName,
+		TokenName: t.Symbol,
+		FullName:  t.Name,
+		Decimals:  t.Decimals,
+		Abi:       IERC20MetadataMetaData.ABI,
+	}
+}
+
+type tokenKey struct {
+	chainID wallet_common.ChainID
+	address common.Address
+}
+
+type symbolKey struct {
+	chainID wallet_common.ChainID
+	symbol  string
+}
+
+// TokenRegistry holds the known ERC20 tokens of every chain, indexed by address
+// and by symbol. It is safe for concurrent use and can be extended at runtime.
+type TokenRegistry struct {
+	mu       sync.RWMutex
+	tokens   map[tokenKey]*ERC20Contract
+	bySymbol map[symbolKey][]common.Address
+}
+
+func NewTokenRegistry() *TokenRegistry {
+	return &TokenRegistry{
+		tokens:   make(map[tokenKey]*ERC20Contract),
+		bySymbol: make(map[symbolKey][]common.Address),
+	}
+}
+
+// LoadTokenList registers every token of a Uniswap token list, replacing
+// already known tokens with the same chain and address.
+func (r *TokenRegistry) LoadTokenList(data []byte) error {
+	var list TokenList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.Wrap(err, "failed to decode token list")
+	}
+	for i := range list.Tokens {
+		if err := r.Register(list.Tokens[i].Contract()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TokenRegistry) Register(contract ERC20Contract) error {
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+	address := common.HexToAddress(contract.Address)
+	contract.Address = address.Hex()
+	key := tokenKey{contract.ChainID, address}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.tokens[key]; ok {
+		r.removeSymbol(old, address)
+	}
+	r.tokens[key] = &contract
+	sym := symbolKey{contract.ChainID, strings.ToUpper(contract.TokenName)}
+	r.bySymbol[sym] = append(r.bySymbol[sym], address)
+	return nil
+}
+
+func (r *TokenRegistry) removeSymbol(contract *ERC20Contract, address common.Address) {
+	sym := symbolKey{contract.ChainID, strings.ToUpper(contract.TokenName)}
+	addresses := r.bySymbol[sym][:0]
+	for _, a := range r.bySymbol[sym] {
+		if a != address {
+			addresses = append(addresses, a)
+		}
+	}
+	if len(addresses) == 0 {
+		delete(r.bySymbol, sym)
+	} else {
+		r.bySymbol[sym] = addresses
+	}
+}
+
+// Get returns a copy of the token at address on chainID.
+func (r *TokenRegistry) Get(chainID wallet_common.ChainID, address common.Address) (*ERC20Contract, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contract, ok := r.tokens[tokenKey{chainID, address}]
+	if !ok {
+		return nil, false
+	}
+	cp := *contract
+	return &cp, true
+}
+
+func (r *TokenRegistry) MustGet(chainID wallet_common.ChainID, address common.Address) *ERC20Contract {
+	contract, ok := r.Get(chainID, address)
+	if !ok {
+		panic(errors.Wrapf(ErrUnknownToken, "%s on chain %d", address.Hex(), chainID))
+	}
+	return contract
+}
+
+// GetBySymbol returns the token of chainID with symbol, ignoring case. It
+// returns false when several tokens of the chain share the symbol, as picking
+// one of them could send funds to the wrong contract.
+func (r *TokenRegistry) GetBySymbol(chainID wallet_common.ChainID, symbol string) (*ERC20Contract, bool) {
+	r.mu.RLock()
+	addresses := r.bySymbol[symbolKey{chainID, strings.ToUpper(symbol)}]
+	r.mu.RUnlock()
+	if len(addresses) != 1 {
+		return nil, false
+	}
+	return r.Get(chainID, addresses[0])
+}
+
+// Tokens returns the tokens of chainID sorted by symbol and address.
+func (r *TokenRegistry) Tokens(chainID wallet_common.ChainID) []*ERC20Contract {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tokens []*ERC20Contract
+	for key, contract := range r.tokens {
+		if key.chainID == chainID {
+			cp := *contract
+			tokens = append(tokens, &cp)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].TokenName != tokens[j].TokenName {
+			return tokens[i].TokenName < tokens[j].TokenName
+		}
+		return tokens[i].Address < tokens[j].Address
+	})
+	return tokens
+}
+
+// ValidateOnChain checks the decimals and symbol of every token of chainID
+// against the contracts, read through resolver which must be connected to
+// chainID. All mismatches are reported in the returned error.
+func (r *TokenRegistry) ValidateOnChain(ctx context.Context, chainID wallet_common.ChainID, resolver *MetadataResolver) error {
+	var mismatches []string
+	for _, token := range r.Tokens(chainID) {
+		metadata, err := resolver.Resolve(ctx, common.HexToAddress(token.Address))
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			mismatches = append(mismatches, fmt.Sprintf("%s %s: %s", token.TokenName, token.Address, err))
+			continue
+		}
+		if metadata.Decimals != token.Decimals {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s: %d decimals on chain, %d in the list", token.TokenName, token.Address, metadata.Decimals, token.Decimals))
+		}
+		if metadata.Symbol != token.TokenName {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s: symbol %q on chain", token.TokenName, token.Address, metadata.Symbol))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.Wrapf(ErrTokenMismatch, "chain %d: %s", chainID, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// DefaultTokenRegistry is loaded from the embedded tokens.json.
+var DefaultTokenRegistry = newDefaultTokenRegistry()
+
+func newDefaultTokenRegistry() *TokenRegistry {
+	registry := NewTokenRegistry()
+	if err := registry.LoadTokenList(defaultTokensJSON); err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// RegisterToken adds or replaces a token in the default registry.
+func RegisterToken(contract ERC20Contract) error {
+	return DefaultTokenRegistry.Register(contract)
+}
+
+// GetToken looks a token up in the default registry.
+func GetToken(chainID wallet_common.ChainID, address common.Address) (*ERC20Contract, bool) {
+	return DefaultTokenRegistry.Get(chainID, address)
+}
+
+// GetTokenBySymbol looks a token up by symbol in the default registry.
+func GetTokenBySymbol(chainID wallet_common.ChainID, symbol string) (*ERC20Contract, bool) {
+	return DefaultTokenRegistry.GetBySymbol(chainID, symbol)
+}
@@ -0,0 +1,89 @@
+package erc20
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenList = `{
+  "name": "test",
+  "timestamp": "2024-01-01T00:00:00.000Z",
+  "version": { "major": 1, "minor": 0, "patch": 0 },
+  "tokens": [
+    { "chainId": 1, "address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "name": "USD Coin", "symbol": "USDC", "decimals": 6 },
+    { "chainId": 1, "address": "0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2", "name": "Maker", "symbol": "MKR", "decimals": 8 },
+    { "chainId": 1, "address": "0x0000000000000000000000000000000000003333", "name": "Fake", "symbol": "FAKE", "decimals": 18 },
+    { "chainId": 10, "address": "0x0000000000000000000000000000000000005555", "name": "Bridged USD Coin", "symbol": "USDC.e", "decimals": 6 },
+    { "chainId": 10, "address": "0x0000000000000000000000000000000000006666", "name": "Old USDC", "symbol": "usdc.e", "decimals": 6 }
+  ]
+}`
+
+func TestDefaultTokenRegistry(t *testing.T) {
+	usdt, ok := GetTokenBySymbol(wallet_common.ChainID(wallet_common.EthereumMainnet), "usdt")
+	require.True(t, ok)
+	require.Equal(t, USDT_CONTRACT_ADDRESS, usdt.GetContractAddress())
+	require.Equal(t, uint8(6), usdt.GetDecimals())
+	require.Equal(t, UsdtContract, usdt)
+
+	bscUSDT, ok := GetTokenBySymbol(56, "USDT")
+	require.True(t, ok)
+	require.Equal(t, uint8(18), bscUSDT.Decimals)
+	require.NotEqual(t, usdt.Address, bscUSDT.Address)
+
+	_, ok = GetToken(56, common.HexToAddress(USDT_CONTRACT_ADDRESS))
+	require.False(t, ok)
+}
+
+func TestTokenRegistry(t *testing.T) {
+	registry := NewTokenRegistry()
+	require.NoError(t, registry.LoadTokenList([]byte(testTokenList)))
+
+	// addresses are checksummed on import
+	usdc, ok := registry.Get(1, usdcAddress)
+	require.True(t, ok)
+	require.Equal(t, usdcAddress.Hex(), usdc.Address)
+	require.Equal(t, "USD Coin", usdc.FullName)
+	usdc.TokenName = "changed"
+	usdc, _ = registry.Get(1, usdcAddress)
+	require.Equal(t, "USDC", usdc.TokenName)
+
+	// ambiguous symbols are not resolved
+	_, ok = registry.GetBySymbol(10, "USDC.e")
+	require.False(t, ok)
+	require.NoError(t, registry.Register(ERC20Contract{ChainID: 10, Address: "0x0000000000000000000000000000000000006666", TokenName: "USDC.old"}))
+	bridged, ok := registry.GetBySymbol(10, "usdc.E")
+	require.True(t, ok)
+	require.Equal(t, "Bridged USD Coin", bridged.FullName)
+
+	tokens := registry.Tokens(1)
+	require.Len(t, tokens, 3)
+	require.Equal(t, "FAKE", tokens[0].TokenName)
+	require.Equal(t, "USDC", tokens[2].TokenName)
+
+	require.ErrorIs(t, registry.Register(ERC20Contract{ChainID: 1, Address: "0x1234", TokenName: "X"}), ErrInvalidToken)
+	require.ErrorIs(t, registry.Register(ERC20Contract{Address: usdcAddress.Hex(), TokenName: "X"}), ErrInvalidToken)
+	require.ErrorIs(t, registry.LoadTokenList([]byte(`{"tokens":[{"chainId":1,"address":"0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2","decimals":18}]}`)), ErrInvalidToken)
+}
+
+func TestTokenRegistryValidateOnChain(t *testing.T) {
+	ctx := context.Background()
+	resolver, _ := newTestResolver(t)
+
+	registry := NewTokenRegistry()
+	require.NoError(t, registry.LoadTokenList([]byte(testTokenList)))
+	err := registry.ValidateOnChain(ctx, 1, resolver)
+	require.ErrorIs(t, err, ErrTokenMismatch)
+	require.Contains(t, err.Error(), "MKR 0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2: 18 decimals on chain, 8 in the list")
+	require.Contains(t, err.Error(), "FAKE 0x0000000000000000000000000000000000003333")
+	require.NotContains(t, err.Error(), "USDC")
+
+	valid := NewTokenRegistry()
+	usdc, _ := registry.Get(1, usdcAddress)
+	require.NoError(t, valid.Register(*usdc))
+	require.NoError(t, valid.Register(ERC20Contract{ChainID: 1, Address: mkrAddress.Hex(), TokenName: "MKR", Decimals: 18}))
+	require.NoError(t, valid.ValidateOnChain(ctx, 1, resolver))
+}
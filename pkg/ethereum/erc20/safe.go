@@ -0,0 +1,244 @@
+package erc20
+
+import (
+	"context"
+	"math/big"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrCallReverted      = errors.New("token call reverted")
+	ErrCallReturnedFalse = errors.New("token call returned false")
+)
+
+// checkCall dry-runs a token call the way OpenZeppelin's SafeERC20 checks it:
+// tokens that return nothing, like USDT, succeed unless they revert, and tokens
+// that return a bool must return true.
+func (t *Token) checkCall(ctx context.Context, args _types.SendTxArgs) error {
+	result, err := t.Client().CallContract(ctx, goethereum.CallMsg{From: args.From, To: args.To, Data: args.GetInput()}, nil)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return errors.Wrap(ErrCallReverted, err.Error())
+		}
+		return err
+	}
+	return checkReturnData(result)
+}
+
+func checkReturnData(result []byte) error {
+	if len(result) == 0 {
+		return nil
+	}
+	if len(result) < 32 || new(big.Int).SetBytes(result[:32]).Cmp(common.Big1) != 0 {
+		return errors.Wrapf(ErrCallReturnedFalse, "returned %s", hexutil.Encode(result))
+	}
+	return nil
+}
+
+// Approve lets spender transfer up to amount base units of signer's tokens.
+// Tokens like USDT revert when a nonzero allowance is changed to another
+// nonzero value; for them the allowance is reset to zero first, in a separate
+// transaction sent right before the approval. Both are simulated together with
+// eth_simulateV1 before either is sent.
+func (t *Token) Approve(ctx context.Context, signer ethereum.Signer, spender common.Address, amount *big.Int) (_types.Hash, error) {
+	owner := signer.Address()
+	args, err := t.ApproveArgs(owner, spender, amount)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+
+	checkErr := t.checkCall(ctx, args)
+	if checkErr == nil {
+		hash, _, err := t.Send(ctx, signer, args, -1)
+		return hash, err
+	}
+	if !errors.Is(checkErr, ErrCallReverted) || amount.Sign() == 0 {
+		return _types.Hash{}, checkErr
+	}
+	current, err := t.Allowance(ctx, owner, spender)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	if current.Sign() == 0 {
+		return _types.Hash{}, checkErr
+	}
+
+	resetArgs, err := t.ApproveArgs(owner, spender, new(big.Int))
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	// nothing is sent unless the approval goes through after the reset, so
+	// that tokens reverting for other reasons don't end up with a revoked
+	// allowance
+	results, err := t.simulate(ctx, []simulateCall{
+		{From: owner, To: t.Address(), Input: resetArgs.GetInput()},
+		{From: owner, To: t.Address(), Input: args.GetInput()},
+	})
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	for i, step := range []string{"simulated allowance reset", "simulated approval"} {
+		if results[i].Status != 1 {
+			return _types.Hash{}, errors.Wrap(ErrCallReverted, step)
+		}
+		if err := checkReturnData(results[i].ReturnData); err != nil {
+			return _types.Hash{}, errors.Wrap(err, step)
+		}
+	}
+	_, nonce, err := t.Send(ctx, signer, resetArgs, -1)
+	if err != nil {
+		return _types.Hash{}, errors.Wrap(err, "failed to reset allowance")
+	}
+	// the approval can't be estimated before the reset is mined
+	if args.Gas == nil {
+		gas := hexutil.Uint64(ethereum.ERC20ApproveGas)
+		args.Gas = &gas
+	}
+	hash, _, err := t.Send(ctx, signer, args, int64(nonce))
+	return hash, err
+}
+
+type TransferBehavior int
+
+const (
+	// TransferStandard moves exactly the requested amount.
+	TransferStandard TransferBehavior = iota
+	// TransferFeeOnTransfer debits the amount but credits less of it.
+	TransferFeeOnTransfer
+	// TransferRebasing debits or credits other amounts than requested, as
+	// tokens accounting in shares do through rounding.
+	TransferRebasing
+)
+
+func (b TransferBehavior) String() string {
+	switch b {
+	case TransferStandard:
+		return "standard"
+	case TransferFeeOnTransfer:
+		return "fee-on-transfer"
+	case TransferRebasing:
+		return "rebasing"
+	}
+	return "unknown"
+}
+
+// TransferSimulation is the outcome of a simulated transfer.
+type TransferSimulation struct {
+	Amount   *big.Int
+	Sent     *big.Int
+	Received *big.Int
+	Behavior TransferBehavior
+}
+
+// Fee returns how much of the amount didn't reach the recipient.
+func (s *TransferSimulation) Fee() *big.Int {
+	return new(big.Int).Sub(s.Sent, s.Received)
+}
+
+type simulateCall struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+}
+
+type simulateBlock struct {
+	Calls []simulateCall `json:"calls"`
+}
+
+type simulateOpts struct {
+	BlockStateCalls []simulateBlock `json:"blockStateCalls"`
+}
+
+type simulateCallResult struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Status     hexutil.Uint64 `json:"status"`
+}
+
+type simulateBlockResult struct {
+	Calls []simulateCallResult `json:"calls"`
+}
+
+// simulate runs calls one after the other in a single block on top of the
+// latest state with eth_simulateV1.
+func (t *Token) simulate(ctx context.Context, calls []simulateCall) ([]simulateCallResult, error) {
+	var blocks []simulateBlockResult
+	opts := simulateOpts{BlockStateCalls: []simulateBlock{{Calls: calls}}}
+	if err := t.Client().Client().CallContext(ctx, &blocks, "eth_simulateV1", opts, "latest"); err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, errors.New("eth_simulateV1: unexpected result shape")
+	}
+	return blocks[0].Calls, nil
+}
+
+// SimulateTransfer runs a transfer with eth_simulateV1 and compares the
+// balance changes of both sides with amount, to detect fee-on-transfer and
+// rebasing tokens before they break accounting.
+func (t *Token) SimulateTransfer(ctx context.Context, from common.Address, to common.Address, amount *big.Int) (*TransferSimulation, error) {
+	if from == to {
+		return nil, errors.Wrap(ErrInvalidAmount, "can't simulate a transfer to the sender")
+	}
+	balanceOf := func(owner common.Address) (simulateCall, error) {
+		data, err := t.ABI().Pack("balanceOf", owner)
+		return simulateCall{From: from, To: t.Address(), Input: data}, err
+	}
+	transfer, err := t.TransferArgs(from, to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]simulateCall, 0, 5)
+	for _, owner := range []common.Address{from, to} {
+		call, err := balanceOf(owner)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	calls = append(calls, simulateCall{From: from, To: t.Address(), Input: transfer.GetInput()})
+	calls = append(calls, calls[0], calls[1])
+
+	results, err := t.simulate(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+	if results[2].Status != 1 {
+		return nil, errors.Wrap(ErrCallReverted, "simulated transfer")
+	}
+	if err := checkReturnData(results[2].ReturnData); err != nil {
+		return nil, err
+	}
+
+	balances := make([]*big.Int, len(results))
+	for i, result := range results {
+		if i == 2 {
+			continue
+		}
+		if result.Status != 1 || len(result.ReturnData) != 32 {
+			return nil, errors.Wrap(ErrCallReverted, "simulated balanceOf")
+		}
+		balances[i] = new(big.Int).SetBytes(result.ReturnData)
+	}
+
+	simulation := &TransferSimulation{
+		Amount:   amount,
+		Sent:     new(big.Int).Sub(balances[0], balances[3]),
+		Received: new(big.Int).Sub(balances[4], balances[1]),
+	}
+	switch {
+	case simulation.Sent.Cmp(amount) == 0 && simulation.Received.Cmp(amount) == 0:
+		simulation.Behavior = TransferStandard
+	case simulation.Sent.Cmp(amount) == 0 && simulation.Received.Cmp(amount) < 0:
+		simulation.Behavior = TransferFeeOnTransfer
+	default:
+		simulation.Behavior = TransferRebasing
+	}
+	return simulation, nil
+}
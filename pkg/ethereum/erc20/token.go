@@ -0,0 +1,137 @@
+package erc20
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrTransactionFailed = errors.New("transaction failed")
+	ErrTransferNotFound  = errors.New("transfer log not found in receipt")
+)
+
+// Token reads balances of an ERC20 token and sends its transfers through a
+// Transactor.
+type Token struct {
+	*ethereum.ContractClient
+	decimals uint8
+	contract *IERC20
+}
+
+func NewToken(
+	address common.Address,
+	decimals uint8,
+	chainID *big.Int,
+	client *ethclient.Client,
+	transactor *ethereum.Transactor,
+) (*Token, error) {
+	contract, err := NewIERC20(address, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := IERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		ContractClient: ethereum.NewContractClient(address, parsed, chainID, client, transactor),
+		decimals:       decimals,
+		contract:       contract,
+	}, nil
+}
+
+func (t *Token) Decimals() uint8 {
+	return t.decimals
+}
+
+// ParseAmount converts a decimal amount into base units of the token.
+func (t *Token) ParseAmount(s string) (*big.Int, error) {
+	return ParseAmount(s, t.decimals)
+}
+
+// FormatAmount renders base units of the token as a decimal amount.
+func (t *Token) FormatAmount(amount *big.Int) string {
+	return FormatAmount(amount, t.decimals)
+}
+
+func (t *Token) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+}
+
+func (t *Token) Allowance(ctx context.Context, owner common.Address, spender common.Address) (*big.Int, error) {
+	return t.contract.Allowance(&bind.CallOpts{Context: ctx}, owner, spender)
+}
+
+// TransferArgs returns the arguments of a transfer of amount base units from
+// from to to. Gas and fees are left to the transactor.
+func (t *Token) TransferArgs(from common.Address, to common.Address, amount *big.Int) (_types.SendTxArgs, error) {
+	return t.CallArgs(from, "transfer", to, amount)
+}
+
+// ApproveArgs returns the arguments of an approval of spender for amount.
+func (t *Token) ApproveArgs(owner common.Address, spender common.Address, amount *big.Int) (_types.SendTxArgs, error) {
+	return t.CallArgs(owner, "approve", spender, amount)
+}
+
+// TransferFromArgs returns the arguments of a transfer by spender out of the
+// allowance from gave it.
+func (t *Token) TransferFromArgs(spender common.Address, from common.Address, to common.Address, amount *big.Int) (_types.SendTxArgs, error) {
+	return t.CallArgs(spender, "transferFrom", from, to, amount)
+}
+
+// Transfer sends amount base units to to, signed by signer.
+func (t *Token) Transfer(ctx context.Context, signer ethereum.Signer, to common.Address, amount *big.Int) (_types.Hash, error) {
+	args, err := t.TransferArgs(signer.Address(), to, amount)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	return t.checkAndSend(ctx, signer, args)
+}
+
+// TransferFrom moves amount base units from from to to out of signer's allowance.
+func (t *Token) TransferFrom(ctx context.Context, signer ethereum.Signer, from common.Address, to common.Address, amount *big.Int) (_types.Hash, error) {
+	args, err := t.TransferFromArgs(signer.Address(), from, to, amount)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	return t.checkAndSend(ctx, signer, args)
+}
+
+// checkAndSend sends args once a dry run shows the call succeeds.
+func (t *Token) checkAndSend(ctx context.Context, signer ethereum.Signer, args _types.SendTxArgs) (_types.Hash, error) {
+	if err := t.checkCall(ctx, args); err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// VerifyTransfer checks that receipt succeeded and contains a Transfer log of
+// this token moving amount from from to to.
+func (t *Token) VerifyTransfer(receipt *types.Receipt, from common.Address, to common.Address, amount *big.Int) (*IERC20Transfer, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.Wrapf(ErrTransactionFailed, "tx %s", receipt.TxHash.Hex())
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != t.Address() {
+			continue
+		}
+		transfer, err := t.contract.ParseTransfer(*log)
+		if err != nil {
+			// Approval or another event of the token
+			continue
+		}
+		if transfer.From == from && transfer.To == to && transfer.Value.Cmp(amount) == 0 {
+			return transfer, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrTransferNotFound, "%s from %s to %s in tx %s", t.FormatAmount(amount), from.Hex(), to.Hex(), receipt.TxHash.Hex())
+}
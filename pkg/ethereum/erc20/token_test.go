@@ -0,0 +1,414 @@
+package erc20
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/internal/ethtest"
+	"github.com/stretchr/testify/require"
+)
+
+var testTokenAddress = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+type tokenMode int
+
+const (
+	modeStandard tokenMode = iota
+	// modeUSDT returns nothing from transfer and approve, and reverts when a
+	// nonzero allowance is changed to another nonzero value.
+	modeUSDT
+	// modeFee burns 1% of every transfer.
+	modeFee
+	// modeReturnsFalse returns false instead of reverting on failures.
+	modeReturnsFalse
+	// modeBlocklist reverts nonzero approvals, like tokens blocking a spender.
+	modeBlocklist
+)
+
+var errReverted = errors.New("execution reverted")
+
+type tokenState struct {
+	balances       map[common.Address]*big.Int
+	allowances     map[[2]common.Address]*big.Int
+	permitNonces   map[common.Address]uint64
+	authorizations map[authorizationKey]bool
+}
+
+func (s *tokenState) clone() *tokenState {
+	cp := newTokenState()
+	for k, v := range s.balances {
+		cp.balances[k] = new(big.Int).Set(v)
+	}
+	for k, v := range s.allowances {
+		cp.allowances[k] = new(big.Int).Set(v)
+	}
+	for k, v := range s.permitNonces {
+		cp.permitNonces[k] = v
+	}
+	for k, v := range s.authorizations {
+		cp.authorizations[k] = v
+	}
+	return cp
+}
+
+func newTokenState() *tokenState {
+	return &tokenState{
+		balances:       make(map[common.Address]*big.Int),
+		allowances:     make(map[[2]common.Address]*big.Int),
+		permitNonces:   make(map[common.Address]uint64),
+		authorizations: make(map[authorizationKey]bool),
+	}
+}
+
+func (s *tokenState) balance(owner common.Address) *big.Int {
+	if b, ok := s.balances[owner]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+func (s *tokenState) allowance(owner, spender common.Address) *big.Int {
+	if a, ok := s.allowances[[2]common.Address{owner, spender}]; ok {
+		return a
+	}
+	return new(big.Int)
+}
+
+// fakeTokenChain is an in-process node running a single ERC20 token.
+type fakeTokenChain struct {
+	*ethtest.Chain
+	abi   *abi.ABI
+	mode  tokenMode
+	state *tokenState
+	// permit is the signed approvals support of the token, nil if it has none
+	permit *fakePermit
+}
+
+func newFakeTokenChain(t *testing.T) *fakeTokenChain {
+	parsed, err := IERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	c := &fakeTokenChain{abi: parsed, state: newTokenState()}
+	c.Chain = ethtest.NewChain(c)
+	return c
+}
+
+func (c *fakeTokenChain) Run(sender common.Address, input []byte) ([]byte, []*types.Log, error) {
+	return c.run(c.state, sender, input)
+}
+
+func (c *fakeTokenChain) Snapshot() interface{} {
+	return c.state.clone()
+}
+
+func (c *fakeTokenChain) Revert(snapshot interface{}) {
+	c.state = snapshot.(*tokenState)
+}
+
+// run executes a token call of sender on state and returns its return data and
+// events.
+func (c *fakeTokenChain) run(state *tokenState, sender common.Address, input []byte) ([]byte, []*types.Log, error) {
+	method, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return c.runPermit(state, sender, input)
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+
+	success := func(logs ...*types.Log) ([]byte, []*types.Log, error) {
+		if c.mode == modeUSDT {
+			return nil, logs, nil
+		}
+		ret, _ := method.Outputs.Pack(true)
+		return ret, logs, nil
+	}
+	failure := func() ([]byte, []*types.Log, error) {
+		if c.mode == modeReturnsFalse {
+			ret, _ := method.Outputs.Pack(false)
+			return ret, nil, nil
+		}
+		return nil, nil, errReverted
+	}
+	move := func(from, to common.Address, amount *big.Int) ([]byte, []*types.Log, error) {
+		if state.balance(from).Cmp(amount) < 0 {
+			return failure()
+		}
+		received := amount
+		var logs []*types.Log
+		if c.mode == modeFee {
+			fee := new(big.Int).Div(amount, big.NewInt(100))
+			received = new(big.Int).Sub(amount, fee)
+			logs = append(logs, c.event("Transfer", from, common.Address{}, fee))
+		}
+		state.balances[from] = new(big.Int).Sub(state.balance(from), amount)
+		state.balances[to] = new(big.Int).Add(state.balance(to), received)
+		return success(append(logs, c.event("Transfer", from, to, received))...)
+	}
+
+	switch method.Name {
+	case "balanceOf":
+		ret, _ := method.Outputs.Pack(state.balance(values[0].(common.Address)))
+		return ret, nil, nil
+	case "allowance":
+		ret, _ := method.Outputs.Pack(state.allowance(values[0].(common.Address), values[1].(common.Address)))
+		return ret, nil, nil
+	case "transfer":
+		return move(sender, values[0].(common.Address), values[1].(*big.Int))
+	case "approve":
+		spender, amount := values[0].(common.Address), values[1].(*big.Int)
+		if c.mode == modeUSDT && amount.Sign() != 0 && state.allowance(sender, spender).Sign() != 0 {
+			return nil, nil, errReverted
+		}
+		if c.mode == modeBlocklist && amount.Sign() != 0 {
+			return nil, nil, errReverted
+		}
+		state.allowances[[2]common.Address{sender, spender}] = amount
+		return success(c.event("Approval", sender, spender, amount))
+	case "transferFrom":
+		from, amount := values[0].(common.Address), values[2].(*big.Int)
+		allowance := state.allowance(from, sender)
+		if allowance.Cmp(amount) < 0 {
+			return failure()
+		}
+		state.allowances[[2]common.Address{from, sender}] = new(big.Int).Sub(allowance, amount)
+		return move(from, values[1].(common.Address), amount)
+	}
+	return nil, nil, errReverted
+}
+
+func (c *fakeTokenChain) event(name string, a, b common.Address, amount *big.Int) *types.Log {
+	data, _ := c.abi.Events[name].Inputs.NonIndexed().Pack(amount)
+	return &types.Log{
+		Address:     testTokenAddress,
+		Topics:      []common.Hash{c.abi.Events[name].ID, common.BytesToHash(a[:]), common.BytesToHash(b[:])},
+		Data:        data,
+		BlockNumber: 1,
+	}
+}
+
+func newTestToken(t *testing.T, chain *fakeTokenChain) *Token {
+	client := ethtest.Dial(t, chain.Chain)
+	token, err := NewToken(testTokenAddress, 6, ethtest.ChainID, client, ethereum.NewTransactor(client, ethtest.ChainID, nil))
+	require.NoError(t, err)
+	token.SetReceiptPollInterval(10 * time.Millisecond)
+	return token
+}
+
+func TestAmount(t *testing.T) {
+	testCases := []struct {
+		s         string
+		decimals  uint8
+		amount    string
+		formatted string
+	}{
+		{"1", 6, "1000000", "1"},
+		{"1.5", 6, "1500000", "1.5"},
+		{"0.000001", 6, "1", "0.000001"},
+		{".25", 2, "25", "0.25"},
+		{"12.", 2, "1200", "12"},
+		{"007", 0, "7", "7"},
+		{"1.000000000000000001", 18, "1000000000000000001", "1.000000000000000001"},
+	}
+	for _, tc := range testCases {
+		amount, err := ParseAmount(tc.s, tc.decimals)
+		require.NoError(t, err, tc.s)
+		require.Equal(t, tc.amount, amount.String())
+		require.Equal(t, tc.formatted, FormatAmount(amount, tc.decimals))
+	}
+	require.Equal(t, "-0.5", FormatAmount(big.NewInt(-50), 2))
+	require.Equal(t, "0", FormatAmount(new(big.Int), 18))
+
+	for _, s := range []string{"", ".", "-1", "1e6", "1,5", "1.2.3", "0.0000001"} {
+		_, err := ParseAmount(s, 6)
+		require.ErrorIs(t, err, ErrInvalidAmount, s)
+	}
+}
+
+func TestToken(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	spenderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	spender := ethereum.NewPrivateKeySigner(spenderKey)
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	chain.state.balances[owner.Address()] = big.NewInt(10_000_000)
+	balance, err := token.BalanceOf(ctx, owner.Address())
+	require.NoError(t, err)
+	require.Equal(t, "10", token.FormatAmount(balance))
+
+	amount, err := token.ParseAmount("2.5")
+	require.NoError(t, err)
+	hash, err := token.Transfer(ctx, owner, recipient, amount)
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	transfer, err := token.VerifyTransfer(receipt, owner.Address(), recipient, amount)
+	require.NoError(t, err)
+	require.Equal(t, amount, transfer.Value)
+
+	_, err = token.VerifyTransfer(receipt, owner.Address(), recipient, big.NewInt(1))
+	require.ErrorIs(t, err, ErrTransferNotFound)
+
+	balance, err = token.BalanceOf(ctx, recipient)
+	require.NoError(t, err)
+	require.Equal(t, amount, balance)
+
+	// approve and spend the allowance
+	hash, err = token.Approve(ctx, owner, spender.Address(), big.NewInt(1_000_000))
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	_, err = token.VerifyTransfer(receipt, owner.Address(), spender.Address(), big.NewInt(1_000_000))
+	require.ErrorIs(t, err, ErrTransferNotFound)
+
+	allowance, err := token.Allowance(ctx, owner.Address(), spender.Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_000_000), allowance)
+
+	hash, err = token.TransferFrom(ctx, spender, owner.Address(), recipient, big.NewInt(400_000))
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	_, err = token.VerifyTransfer(receipt, owner.Address(), recipient, big.NewInt(400_000))
+	require.NoError(t, err)
+
+	allowance, err = token.Allowance(ctx, owner.Address(), spender.Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(600_000), allowance)
+
+	// spending beyond the allowance is caught by the dry run
+	_, err = token.TransferFrom(ctx, spender, owner.Address(), recipient, big.NewInt(700_000))
+	require.ErrorIs(t, err, ErrCallReverted)
+
+	_, err = token.VerifyTransfer(&types.Receipt{Status: types.ReceiptStatusFailed}, owner.Address(), recipient, amount)
+	require.ErrorIs(t, err, ErrTransactionFailed)
+
+	args, err := token.TransferArgs(owner.Address(), recipient, amount)
+	require.NoError(t, err)
+	require.Equal(t, testTokenAddress, *args.To)
+	require.Equal(t, "a9059cbb", common.Bytes2Hex(args.GetInput()[:4]))
+}
+
+func TestTokenUSDT(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	chain.mode = modeUSDT
+	token := newTestToken(t, chain)
+	// the approval after a reset is sent while the reset is pending
+	chain.MineOnReceipt = true
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	spender := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	chain.state.balances[owner.Address()] = big.NewInt(1_000_000)
+
+	// no return data is a success
+	hash, err := token.Transfer(ctx, owner, recipient, big.NewInt(1000))
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	_, err = token.VerifyTransfer(receipt, owner.Address(), recipient, big.NewInt(1000))
+	require.NoError(t, err)
+
+	// changing a nonzero allowance goes through zero
+	for _, amount := range []int64{500, 700} {
+		hash, err = token.Approve(ctx, owner, spender, big.NewInt(amount))
+		require.NoError(t, err)
+		_, err = token.WaitReceipt(ctx, hash)
+		require.NoError(t, err)
+		allowance, err := token.Allowance(ctx, owner.Address(), spender)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(amount), allowance)
+	}
+	require.Equal(t, hexutil.Uint64(4), chain.GetTransactionCount(owner.Address(), "latest"))
+}
+
+func TestTokenApproveBlocked(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	chain.mode = modeBlocklist
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	spender := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	chain.state.allowances[[2]common.Address{owner.Address(), spender}] = big.NewInt(500)
+
+	// the reset would go through, the approval after it wouldn't
+	_, err = token.Approve(ctx, owner, spender, big.NewInt(700))
+	require.ErrorIs(t, err, ErrCallReverted)
+	require.Zero(t, chain.GetTransactionCount(owner.Address(), "latest"))
+	allowance, err := token.Allowance(ctx, owner.Address(), spender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(500), allowance)
+}
+
+func TestTokenReturnsFalse(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeTokenChain(t)
+	chain.mode = modeReturnsFalse
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+
+	_, err = token.Transfer(ctx, owner, common.HexToAddress("0x000000000000000000000000000000000000dEaD"), big.NewInt(1))
+	require.ErrorIs(t, err, ErrCallReturnedFalse)
+	require.Zero(t, chain.GetTransactionCount(owner.Address(), "latest"))
+}
+
+func TestSimulateTransfer(t *testing.T) {
+	ctx := context.Background()
+	from := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	testCases := []struct {
+		mode     tokenMode
+		behavior TransferBehavior
+		received int64
+	}{
+		{modeStandard, TransferStandard, 10_000},
+		{modeUSDT, TransferStandard, 10_000},
+		{modeFee, TransferFeeOnTransfer, 9_900},
+	}
+	for _, tc := range testCases {
+		chain := newFakeTokenChain(t)
+		chain.mode = tc.mode
+		chain.state.balances[from] = big.NewInt(50_000)
+		token := newTestToken(t, chain)
+
+		simulation, err := token.SimulateTransfer(ctx, from, to, big.NewInt(10_000))
+		require.NoError(t, err)
+		require.Equal(t, tc.behavior, simulation.Behavior, tc.behavior.String())
+		require.Equal(t, big.NewInt(10_000), simulation.Sent)
+		require.Equal(t, big.NewInt(tc.received), simulation.Received)
+		require.Zero(t, big.NewInt(10_000-tc.received).Cmp(simulation.Fee()))
+
+		// the simulation leaves the chain untouched
+		require.Equal(t, big.NewInt(50_000), chain.state.balance(from))
+	}
+
+	chain := newFakeTokenChain(t)
+	_, err := newTestToken(t, chain).SimulateTransfer(ctx, from, to, big.NewInt(1))
+	require.ErrorIs(t, err, ErrCallReverted)
+}
@@ -0,0 +1,75 @@
+package erc721
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ERC721 and ERC20 declare Transfer and Approval with the same signature, so
+// their logs share topic0. ERC721 indexes the token id as a fourth topic while
+// ERC20 puts the amount in the data, which is how the two are told apart.
+const erc721EventTopics = 4
+
+var (
+	ErrNotERC721Log = errors.New("not an ERC721 log")
+
+	TransferTopic       = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	ApprovalTopic       = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	ApprovalForAllTopic = common.HexToHash("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31")
+)
+
+// the filterer only parses logs, which carry their own address
+var filterer, _ = NewIERC721Filterer(common.Address{}, nil)
+
+// IsTransferLog reports whether log is an ERC721 Transfer, as opposed to an
+// ERC20 Transfer with the same topic0.
+func IsTransferLog(log types.Log) bool {
+	return len(log.Topics) == erc721EventTopics && log.Topics[0] == TransferTopic && len(log.Data) == 0
+}
+
+// IsApprovalLog reports whether log is an ERC721 Approval.
+func IsApprovalLog(log types.Log) bool {
+	return len(log.Topics) == erc721EventTopics && log.Topics[0] == ApprovalTopic && len(log.Data) == 0
+}
+
+// IsApprovalForAllLog reports whether log is an ApprovalForAll, which ERC1155
+// declares identically.
+func IsApprovalForAllLog(log types.Log) bool {
+	return len(log.Topics) == 3 && log.Topics[0] == ApprovalForAllTopic
+}
+
+func ParseTransfer(log types.Log) (*IERC721Transfer, error) {
+	if !IsTransferLog(log) {
+		return nil, errors.Wrap(ErrNotERC721Log, "not a Transfer")
+	}
+	return filterer.ParseTransfer(log)
+}
+
+func ParseApproval(log types.Log) (*IERC721Approval, error) {
+	if !IsApprovalLog(log) {
+		return nil, errors.Wrap(ErrNotERC721Log, "not an Approval")
+	}
+	return filterer.ParseApproval(log)
+}
+
+func ParseApprovalForAll(log types.Log) (*IERC721ApprovalForAll, error) {
+	if !IsApprovalForAllLog(log) {
+		return nil, errors.Wrap(ErrNotERC721Log, "not an ApprovalForAll")
+	}
+	return filterer.ParseApprovalForAll(log)
+}
+
+// ParseLog decodes an ERC721 event into *IERC721Transfer, *IERC721Approval
+// or *IERC721ApprovalForAll.
+func ParseLog(log types.Log) (interface{}, error) {
+	switch {
+	case IsTransferLog(log):
+		return ParseTransfer(log)
+	case IsApprovalLog(log):
+		return ParseApproval(log)
+	case IsApprovalForAllLog(log):
+		return ParseApprovalForAll(log)
+	}
+	return nil, ErrNotERC721Log
+}
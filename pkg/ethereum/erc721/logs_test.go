@@ -0,0 +1,58 @@
+package erc721
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLog(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tokenID := big.NewInt(42)
+
+	nft := types.Log{
+		Topics: []common.Hash{TransferTopic, common.BytesToHash(from[:]), common.BytesToHash(to[:]), common.BigToHash(tokenID)},
+	}
+	// the same event emitted by an ERC20 token
+	fungible := types.Log{
+		Topics: []common.Hash{TransferTopic, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+		Data:   common.BigToHash(tokenID).Bytes(),
+	}
+
+	require.True(t, IsTransferLog(nft))
+	require.False(t, IsTransferLog(fungible))
+
+	event, err := ParseLog(nft)
+	require.NoError(t, err)
+	transfer, ok := event.(*IERC721Transfer)
+	require.True(t, ok)
+	require.Equal(t, from, transfer.From)
+	require.Equal(t, to, transfer.To)
+	require.Equal(t, tokenID, transfer.TokenId)
+
+	_, err = ParseLog(fungible)
+	require.ErrorIs(t, err, ErrNotERC721Log)
+	_, err = ParseTransfer(fungible)
+	require.ErrorIs(t, err, ErrNotERC721Log)
+
+	fungible.Topics[0] = ApprovalTopic
+	_, err = ParseApproval(fungible)
+	require.ErrorIs(t, err, ErrNotERC721Log)
+
+	nft.Topics[0] = ApprovalTopic
+	event, err = ParseLog(nft)
+	require.NoError(t, err)
+	require.IsType(t, &IERC721Approval{}, event)
+
+	forAll := types.Log{
+		Topics: []common.Hash{ApprovalForAllTopic, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+		Data:   common.BigToHash(big.NewInt(1)).Bytes(),
+	}
+	event, err = ParseLog(forAll)
+	require.NoError(t, err)
+	require.True(t, event.(*IERC721ApprovalForAll).Approved)
+}
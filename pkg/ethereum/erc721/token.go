@@ -0,0 +1,130 @@
+package erc721
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+)
+
+// Token reads an ERC721 collection and sends its transfers and approvals
+// through a Transactor.
+type Token struct {
+	*ethereum.ContractClient
+	contract *IERC721
+}
+
+func NewToken(
+	address common.Address,
+	chainID *big.Int,
+	client *ethclient.Client,
+	transactor *ethereum.Transactor,
+) (*Token, error) {
+	contract, err := NewIERC721(address, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := IERC721MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		ContractClient: ethereum.NewContractClient(address, parsed, chainID, client, transactor),
+		contract:       contract,
+	}, nil
+}
+
+func (t *Token) OwnerOf(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	return t.contract.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+// BalanceOf returns the number of tokens of the collection held by owner.
+func (t *Token) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+}
+
+// TokenURI returns the metadata URI of tokenID. It fails for collections
+// without the metadata extension.
+func (t *Token) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	return t.contract.TokenURI(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (t *Token) GetApproved(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	return t.contract.GetApproved(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+func (t *Token) IsApprovedForAll(ctx context.Context, owner common.Address, operator common.Address) (bool, error) {
+	return t.contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, owner, operator)
+}
+
+// SafeTransferFromArgs returns the arguments of a safeTransferFrom sent by
+// sender, the owner or an approved operator. The overload without data is
+// used when data is empty.
+func (t *Token) SafeTransferFromArgs(sender common.Address, from common.Address, to common.Address, tokenID *big.Int, data []byte) (_types.SendTxArgs, error) {
+	if len(data) == 0 {
+		return t.CallArgs(sender, "safeTransferFrom", from, to, tokenID)
+	}
+	// abigen names the second overload safeTransferFrom0
+	return t.CallArgs(sender, "safeTransferFrom0", from, to, tokenID, data)
+}
+
+// ApproveArgs returns the arguments of an approval of to for tokenID.
+func (t *Token) ApproveArgs(owner common.Address, to common.Address, tokenID *big.Int) (_types.SendTxArgs, error) {
+	return t.CallArgs(owner, "approve", to, tokenID)
+}
+
+// SetApprovalForAllArgs returns the arguments of an operator approval, which
+// covers every token owner holds in the collection, now or later.
+func (t *Token) SetApprovalForAllArgs(owner common.Address, operator common.Address, approved bool) (_types.SendTxArgs, error) {
+	return t.CallArgs(owner, "setApprovalForAll", operator, approved)
+}
+
+// SafeTransferFrom transfers tokenID from from to to, signed by signer.
+func (t *Token) SafeTransferFrom(ctx context.Context, signer ethereum.Signer, from common.Address, to common.Address, tokenID *big.Int, data []byte) (_types.Hash, error) {
+	args, err := t.SafeTransferFromArgs(signer.Address(), from, to, tokenID, data)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// Approve lets to transfer tokenID, owned by signer.
+func (t *Token) Approve(ctx context.Context, signer ethereum.Signer, to common.Address, tokenID *big.Int) (_types.Hash, error) {
+	args, err := t.ApproveArgs(signer.Address(), to, tokenID)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// SetApprovalForAll lets operator transfer and approve any token of signer in
+// the collection, or revokes it.
+func (t *Token) SetApprovalForAll(ctx context.Context, signer ethereum.Signer, operator common.Address, approved bool) (_types.Hash, error) {
+	args, err := t.SetApprovalForAllArgs(signer.Address(), operator, approved)
+	if err != nil {
+		return _types.Hash{}, err
+	}
+	hash, _, err := t.Send(ctx, signer, args, -1)
+	return hash, err
+}
+
+// Transfers returns the ERC721 transfers of this collection in receipt.
+func (t *Token) Transfers(receipt *types.Receipt) []*IERC721Transfer {
+	var transfers []*IERC721Transfer
+	for _, log := range receipt.Logs {
+		if log.Address != t.Address() {
+			continue
+		}
+		if transfer, err := ParseTransfer(*log); err == nil {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers
+}
@@ -0,0 +1,259 @@
+package erc721
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/internal/ethtest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCollectionAddress = common.HexToAddress("0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D")
+
+	errReverted = errors.New("execution reverted")
+)
+
+type collectionState struct {
+	owners    map[uint64]common.Address
+	approvals map[uint64]common.Address
+	operators map[[2]common.Address]bool
+	lastData  []byte
+}
+
+func newCollectionState() *collectionState {
+	return &collectionState{
+		owners:    make(map[uint64]common.Address),
+		approvals: make(map[uint64]common.Address),
+		operators: make(map[[2]common.Address]bool),
+	}
+}
+
+func (s *collectionState) clone() *collectionState {
+	cp := newCollectionState()
+	for k, v := range s.owners {
+		cp.owners[k] = v
+	}
+	for k, v := range s.approvals {
+		cp.approvals[k] = v
+	}
+	for k, v := range s.operators {
+		cp.operators[k] = v
+	}
+	cp.lastData = s.lastData
+	return cp
+}
+
+func (s *collectionState) balance(owner common.Address) int64 {
+	var n int64
+	for _, o := range s.owners {
+		if o == owner {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeNFTChain is an in-process node running a single ERC721 collection.
+type fakeNFTChain struct {
+	*ethtest.Chain
+	abi   *abi.ABI
+	state *collectionState
+}
+
+func newFakeNFTChain(t *testing.T) *fakeNFTChain {
+	parsed, err := IERC721MetaData.GetAbi()
+	require.NoError(t, err)
+	c := &fakeNFTChain{abi: parsed, state: newCollectionState()}
+	c.Chain = ethtest.NewChain(c)
+	return c
+}
+
+func (c *fakeNFTChain) Snapshot() interface{} {
+	return c.state.clone()
+}
+
+func (c *fakeNFTChain) Revert(snapshot interface{}) {
+	c.state = snapshot.(*collectionState)
+}
+
+// Run executes a collection call of sender and returns its return data and
+// events.
+func (c *fakeNFTChain) Run(sender common.Address, input []byte) ([]byte, []*types.Log, error) {
+	method, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, errReverted
+	}
+
+	switch method.Name {
+	case "ownerOf", "tokenURI", "getApproved":
+		id := values[0].(*big.Int).Uint64()
+		owner, ok := c.state.owners[id]
+		if !ok {
+			return nil, nil, errReverted
+		}
+		var ret []byte
+		switch method.Name {
+		case "ownerOf":
+			ret, _ = method.Outputs.Pack(owner)
+		case "tokenURI":
+			ret, _ = method.Outputs.Pack("ipfs://collection/" + values[0].(*big.Int).String())
+		default:
+			ret, _ = method.Outputs.Pack(c.state.approvals[id])
+		}
+		return ret, nil, nil
+	case "balanceOf":
+		ret, _ := method.Outputs.Pack(big.NewInt(c.state.balance(values[0].(common.Address))))
+		return ret, nil, nil
+	case "isApprovedForAll":
+		ret, _ := method.Outputs.Pack(c.state.operators[[2]common.Address{values[0].(common.Address), values[1].(common.Address)}])
+		return ret, nil, nil
+	case "approve":
+		to, id := values[0].(common.Address), values[1].(*big.Int)
+		owner := c.state.owners[id.Uint64()]
+		if owner != sender && !c.state.operators[[2]common.Address{owner, sender}] {
+			return nil, nil, errReverted
+		}
+		c.state.approvals[id.Uint64()] = to
+		return nil, []*types.Log{c.event("Approval", owner, to, id)}, nil
+	case "setApprovalForAll":
+		operator, approved := values[0].(common.Address), values[1].(bool)
+		c.state.operators[[2]common.Address{sender, operator}] = approved
+		data, _ := c.abi.Events["ApprovalForAll"].Inputs.NonIndexed().Pack(approved)
+		return nil, []*types.Log{{
+			Address:     testCollectionAddress,
+			Topics:      []common.Hash{ApprovalForAllTopic, common.BytesToHash(sender[:]), common.BytesToHash(operator[:])},
+			Data:        data,
+			BlockNumber: 1,
+		}}, nil
+	case "safeTransferFrom", "safeTransferFrom0":
+		from, to, id := values[0].(common.Address), values[1].(common.Address), values[2].(*big.Int)
+		if c.state.owners[id.Uint64()] != from {
+			return nil, nil, errReverted
+		}
+		if sender != from && c.state.approvals[id.Uint64()] != sender && !c.state.operators[[2]common.Address{from, sender}] {
+			return nil, nil, errReverted
+		}
+		c.state.lastData = nil
+		if len(values) == 4 {
+			c.state.lastData = values[3].([]byte)
+		}
+		c.state.owners[id.Uint64()] = to
+		delete(c.state.approvals, id.Uint64())
+		return nil, []*types.Log{c.event("Transfer", from, to, id)}, nil
+	}
+	return nil, nil, errReverted
+}
+
+func (c *fakeNFTChain) event(name string, a, b common.Address, id *big.Int) *types.Log {
+	return &types.Log{
+		Address:     testCollectionAddress,
+		Topics:      []common.Hash{c.abi.Events[name].ID, common.BytesToHash(a[:]), common.BytesToHash(b[:]), common.BigToHash(id)},
+		BlockNumber: 1,
+	}
+}
+
+func newTestToken(t *testing.T, chain *fakeNFTChain) *Token {
+	client := ethtest.Dial(t, chain.Chain)
+	token, err := NewToken(testCollectionAddress, ethtest.ChainID, client, ethereum.NewTransactor(client, ethtest.ChainID, nil))
+	require.NoError(t, err)
+	token.SetReceiptPollInterval(10 * time.Millisecond)
+	return token
+}
+
+func TestToken(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeNFTChain(t)
+	token := newTestToken(t, chain)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	operator := ethereum.NewPrivateKeySigner(operatorKey)
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	chain.state.owners[1] = owner.Address()
+	chain.state.owners[2] = owner.Address()
+
+	got, err := token.OwnerOf(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, owner.Address(), got)
+	balance, err := token.BalanceOf(ctx, owner.Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), balance)
+	uri, err := token.TokenURI(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, "ipfs://collection/2", uri)
+	_, err = token.OwnerOf(ctx, big.NewInt(3))
+	require.Error(t, err)
+
+	// transfer without data
+	hash, err := token.SafeTransferFrom(ctx, owner, owner.Address(), recipient, big.NewInt(1), nil)
+	require.NoError(t, err)
+	receipt, err := token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	transfers := token.Transfers(receipt)
+	require.Len(t, transfers, 1)
+	require.Equal(t, owner.Address(), transfers[0].From)
+	require.Equal(t, recipient, transfers[0].To)
+	require.Equal(t, big.NewInt(1), transfers[0].TokenId)
+	require.Nil(t, chain.state.lastData)
+
+	// approval of a single token
+	hash, err = token.Approve(ctx, owner, operator.Address(), big.NewInt(2))
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	approval, err := ParseApproval(*receipt.Logs[0])
+	require.NoError(t, err)
+	require.Equal(t, operator.Address(), approval.Approved)
+	approved, err := token.GetApproved(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, operator.Address(), approved)
+
+	// operator approval
+	hash, err = token.SetApprovalForAll(ctx, owner, operator.Address(), true)
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	approvalForAll, err := ParseApprovalForAll(*receipt.Logs[0])
+	require.NoError(t, err)
+	require.True(t, approvalForAll.Approved)
+	isApproved, err := token.IsApprovedForAll(ctx, owner.Address(), operator.Address())
+	require.NoError(t, err)
+	require.True(t, isApproved)
+
+	// transfer with data by the operator
+	hash, err = token.SafeTransferFrom(ctx, operator, owner.Address(), recipient, big.NewInt(2), []byte("memo"))
+	require.NoError(t, err)
+	receipt, err = token.WaitReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Len(t, token.Transfers(receipt), 1)
+	require.Equal(t, []byte("memo"), chain.state.lastData)
+
+	balance, err = token.BalanceOf(ctx, recipient)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), balance)
+
+	args, err := token.SafeTransferFromArgs(owner.Address(), owner.Address(), recipient, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Equal(t, testCollectionAddress, *args.To)
+	require.Equal(t, "42842e0e", common.Bytes2Hex(args.GetInput()[:4]))
+	args, err = token.SafeTransferFromArgs(owner.Address(), owner.Address(), recipient, big.NewInt(1), []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, "b88d4fde", common.Bytes2Hex(args.GetInput()[:4]))
+}
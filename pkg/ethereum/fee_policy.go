@@ -0,0 +1,100 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+)
+
+// FeePolicy captures the fee rules of a chain that eth_gasPrice doesn't reflect.
+// It's applied on top of the node's fee oracle before a transaction is built.
+type FeePolicy struct {
+	// EIP1559 is false for chains that only accept legacy transactions.
+	EIP1559 bool
+	// MinGasPrice is the lowest gas price (or max fee) validators accept.
+	MinGasPrice *big.Int
+	// MinPriorityFee is the lowest priority fee validators accept.
+	MinPriorityFee *big.Int
+	// ZeroTip forces the priority fee to zero on chains that ignore it.
+	ZeroTip bool
+}
+
+// DefaultFeePolicy is used for chains missing from the registry and leaves the
+// oracle's values untouched.
+var DefaultFeePolicy = FeePolicy{EIP1559: true}
+
+func FeePolicyForChain(cfg *wallet_common.ChainConfig) FeePolicy {
+	policy := FeePolicy{
+		EIP1559: cfg.EIP1559,
+		ZeroTip: cfg.Fees.ZeroTip,
+	}
+	if cfg.Fees.MinGasPrice > 0 {
+		policy.MinGasPrice = new(big.Int).SetUint64(cfg.Fees.MinGasPrice)
+	}
+	if cfg.Fees.MinPriorityFee > 0 {
+		policy.MinPriorityFee = new(big.Int).SetUint64(cfg.Fees.MinPriorityFee)
+	}
+	return policy
+}
+
+// Normalize rewrites args for chains without EIP-1559 support. Dynamic fee
+// fields are dropped and MaxFeePerGas is returned as the cap of the legacy gas
+// price, nil if there is none.
+func (p FeePolicy) Normalize(args _types.SendTxArgs) (_types.SendTxArgs, *big.Int) {
+	if p.EIP1559 || !args.IsDynamicFeeTx() {
+		return args, nil
+	}
+
+	gasPriceCap := (*big.Int)(args.MaxFeePerGas)
+	args.MaxFeePerGas = nil
+	args.MaxPriorityFeePerGas = nil
+	return args, gasPriceCap
+}
+
+// ApplyLegacy adjusts the gas price of a legacy transaction. gasPriceCap, as
+// returned by Normalize, bounds the oracle's price but not the chain's floor:
+// a transaction priced below the floor would never be mined.
+func (p FeePolicy) ApplyLegacy(gasPrice *big.Int, gasPriceCap *big.Int) *big.Int {
+	gasPrice = new(big.Int).Set(gasPrice)
+	if gasPriceCap != nil && gasPrice.Cmp(gasPriceCap) > 0 {
+		gasPrice.Set(gasPriceCap)
+	}
+	// A legacy gas price pays both the base fee and the tip, so it's at least the tip floor.
+	gasPrice = maxBig(gasPrice, p.MinPriorityFee)
+	return maxBig(gasPrice, p.MinGasPrice)
+}
+
+// ApplyDynamic adjusts the fee cap and priority fee of an EIP-1559 transaction.
+// When the priority fee is raised, the fee cap is raised by the same amount so the
+// headroom reserved for the base fee is kept.
+func (p FeePolicy) ApplyDynamic(args _types.SendTxArgs) _types.SendTxArgs {
+	if !args.IsDynamicFeeTx() {
+		return args
+	}
+
+	gasFeeCap := new(big.Int).Set((*big.Int)(args.MaxFeePerGas))
+	gasTipCap := new(big.Int).Set((*big.Int)(args.MaxPriorityFeePerGas))
+
+	if p.ZeroTip {
+		gasTipCap.SetInt64(0)
+	} else if p.MinPriorityFee != nil && gasTipCap.Cmp(p.MinPriorityFee) < 0 {
+		gasFeeCap.Add(gasFeeCap, new(big.Int).Sub(p.MinPriorityFee, gasTipCap))
+		gasTipCap.Set(p.MinPriorityFee)
+	}
+
+	gasFeeCap = maxBig(gasFeeCap, p.MinGasPrice)
+	gasFeeCap = maxBig(gasFeeCap, gasTipCap)
+
+	args.MaxFeePerGas = (*hexutil.Big)(gasFeeCap)
+	args.MaxPriorityFeePerGas = (*hexutil.Big)(gasTipCap)
+	return args
+}
+
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if b != nil && a.Cmp(b) < 0 {
+		return new(big.Int).Set(b)
+	}
+	return a
+}
@@ -0,0 +1,76 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000))
+}
+
+func policyOf(chainID uint64) FeePolicy {
+	return FeePolicyForChain(wallet_common.DefaultChainRegistry.MustGet(wallet_common.ChainID(chainID)))
+}
+
+func TestFeePolicyDynamic(t *testing.T) {
+	args := _types.SendTxArgs{
+		MaxFeePerGas:         (*hexutil.Big)(gwei(100)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(gwei(2)),
+	}
+
+	polygon := policyOf(wallet_common.PolygonMainnet).ApplyDynamic(args)
+	require.Equal(t, gwei(30), polygon.MaxPriorityFeePerGas.ToInt())
+	require.Equal(t, gwei(128), polygon.MaxFeePerGas.ToInt())
+
+	arbitrum := policyOf(wallet_common.ArbitrumMainnet).ApplyDynamic(args)
+	require.Zero(t, arbitrum.MaxPriorityFeePerGas.ToInt().Sign())
+	require.Equal(t, gwei(100), arbitrum.MaxFeePerGas.ToInt())
+
+	mainnet := policyOf(wallet_common.EthereumMainnet).ApplyDynamic(args)
+	require.Equal(t, args, mainnet)
+}
+
+func TestFeePolicyLegacy(t *testing.T) {
+	bsc := policyOf(wallet_common.BinanceChainID)
+	require.Equal(t, gwei(1), bsc.ApplyLegacy(big.NewInt(100), nil))
+	require.Equal(t, gwei(5), bsc.ApplyLegacy(gwei(5), nil))
+
+	args, gasPriceCap := bsc.Normalize(_types.SendTxArgs{
+		MaxFeePerGas:         (*hexutil.Big)(gwei(3)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(gwei(1)),
+	})
+	require.False(t, args.IsDynamicFeeTx())
+	require.Equal(t, gwei(3), bsc.ApplyLegacy(gwei(7), gasPriceCap))
+}
+
+func TestTransactorAppliesFeePolicy(t *testing.T) {
+	api := newFakeEthAPI(wallet_common.BinanceChainID)
+	api.gasPrice = big.NewInt(1)
+	chainID := big.NewInt(int64(wallet_common.BinanceChainID))
+	transactor := NewTransactor(newFakeClient(api), chainID, nil)
+
+	key, _ := gethcrypto.GenerateKey()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	_, _, err := transactor.SendTransactionWithChainID(context.Background(), chainID, _types.SendTxArgs{
+		From:                 gethcrypto.PubkeyToAddress(key.PublicKey),
+		To:                   &to,
+		MaxFeePerGas:         (*hexutil.Big)(gwei(3)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(gwei(1)),
+	}, NewPrivateKeySigner(key), -1)
+	require.NoError(t, err)
+
+	sent := api.sentTxs()
+	require.Len(t, sent, 1)
+	require.Equal(t, uint8(types.LegacyTxType), sent[0].Type())
+	require.Equal(t, gwei(1), sent[0].GasPrice())
+}
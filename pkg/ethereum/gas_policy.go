@@ -0,0 +1,175 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// ERC20TransferGas covers transfer/transferFrom of common tokens, including
+	// first-time writes to the recipient's balance slot.
+	ERC20TransferGas = 65000
+	// ERC20ApproveGas covers approve from a zero to a nonzero allowance.
+	ERC20ApproveGas = 55000
+)
+
+var (
+	erc20TransferSelector     = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	erc20TransferFromSelector = []byte{0x23, 0xb8, 0x72, 0xdd}
+	erc20ApproveSelector      = []byte{0x09, 0x5e, 0xa7, 0xb3}
+)
+
+type CallType int
+
+const (
+	CallTypeContractCall CallType = iota
+	CallTypeTransfer
+	CallTypeERC20Transfer
+	CallTypeERC20Approve
+	CallTypeContractCreation
+)
+
+// ClassifyCall guesses what a transaction does from its calldata.
+func ClassifyCall(args _types.SendTxArgs) CallType {
+	input := args.GetInput()
+	switch {
+	case args.To == nil:
+		return CallTypeContractCreation
+	case len(input) == 0:
+		return CallTypeTransfer
+	case len(input) < 4:
+		return CallTypeContractCall
+	case bytes.Equal(input[:4], erc20TransferSelector), bytes.Equal(input[:4], erc20TransferFromSelector):
+		return CallTypeERC20Transfer
+	case bytes.Equal(input[:4], erc20ApproveSelector):
+		return CallTypeERC20Approve
+	}
+	return CallTypeContractCall
+}
+
+// GasLimitPolicy turns gas estimates into gas limits.
+type GasLimitPolicy struct {
+	// Multiplier scales the estimate, e.g. 1.2 for 20% headroom. Values <= 1 are ignored.
+	Multiplier float64
+	// Headroom is added on top of the scaled estimate.
+	Headroom uint64
+	// Fallbacks are used when the node fails to estimate a call of the given type.
+	// Calls without a fallback fail with the estimation error.
+	Fallbacks map[CallType]uint64
+}
+
+var DefaultGasLimitPolicy = GasLimitPolicy{
+	Multiplier: 1.2,
+	Fallbacks: map[CallType]uint64{
+		CallTypeTransfer:      params.TxGas,
+		CallTypeERC20Transfer: ERC20TransferGas,
+		CallTypeERC20Approve:  ERC20ApproveGas,
+	},
+}
+
+// Apply adds the safety margin to an estimate. Plain transfers costing exactly
+// the intrinsic gas are left alone, they can't use more.
+func (p GasLimitPolicy) Apply(callType CallType, estimate uint64) uint64 {
+	if callType == CallTypeTransfer && estimate == params.TxGas {
+		return estimate
+	}
+
+	gas := estimate
+	if p.Multiplier > 1 {
+		gas = uint64(float64(estimate) * p.Multiplier)
+	}
+	return gas + p.Headroom
+}
+
+func (p GasLimitPolicy) Fallback(callType CallType) (uint64, bool) {
+	gas, ok := p.Fallbacks[callType]
+	return gas, ok && gas > 0
+}
+
+// revertErrorCode is the JSON-RPC error code geth and most other nodes use
+// for calls that revert.
+const revertErrorCode = 3
+
+// IsRevert reports whether err is the node reporting that a call reverted, as
+// opposed to failing to run it. Every JSON-RPC error carries an ErrorData
+// method, so only errors with revert data, the revert code or the revert
+// message count.
+func IsRevert(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == revertErrorCode {
+		return true
+	}
+	return strings.Contains(err.Error(), "execution reverted")
+}
+
+// isDeterministicEstimateError reports whether the node refused the estimate
+// because the call itself can't succeed, in which case a fallback limit would
+// only get the transaction mined as a failure.
+func isDeterministicEstimateError(err error) bool {
+	if IsRevert(err) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "insufficient funds") ||
+		strings.Contains(msg, "gas required exceeds allowance")
+}
+
+// SetGasLimitPolicy overrides DefaultGasLimitPolicy for this transactor.
+func (t *Transactor) SetGasLimitPolicy(policy GasLimitPolicy) {
+	t.gasPolicy = policy
+}
+
+func (t *Transactor) estimateGasLimit(ctx context.Context, args _types.SendTxArgs, msg ethereum.CallMsg) (uint64, error) {
+	callType := ClassifyCall(args)
+
+	estimate, err := t.client.EstimateGas(ctx, msg)
+	if err != nil {
+		fallback, ok := t.gasPolicy.Fallback(callType)
+		if !ok || isDeterministicEstimateError(err) {
+			return 0, err
+		}
+
+		zap.S().Warnw("gas estimation failed, using fallback gas limit",
+			"from", args.From,
+			"gas", fallback,
+			"error", err,
+		)
+		return t.capToBlockGasLimit(ctx, fallback), nil
+	}
+
+	gas := t.gasPolicy.Apply(callType, estimate)
+	if gas <= estimate {
+		return estimate, nil
+	}
+	return max(t.capToBlockGasLimit(ctx, gas), estimate), nil
+}
+
+// capToBlockGasLimit bounds gas by the gas limit of the latest block. If the
+// block can't be fetched gas is returned unchanged and the node gets to decide.
+func (t *Transactor) capToBlockGasLimit(ctx context.Context, gas uint64) uint64 {
+	header, err := t.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		zap.S().Warnw("failed to fetch block gas limit", "error", err)
+		return gas
+	}
+
+	if header.GasLimit > 0 && gas > header.GasLimit {
+		return header.GasLimit
+	}
+	return gas
+}
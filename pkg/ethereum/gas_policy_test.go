@@ -0,0 +1,110 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyCall(t *testing.T) {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	require.Equal(t, CallTypeContractCreation, ClassifyCall(_types.SendTxArgs{Data: []byte{0x60}}))
+	require.Equal(t, CallTypeTransfer, ClassifyCall(_types.SendTxArgs{To: &to}))
+	require.Equal(t, CallTypeERC20Transfer, ClassifyCall(_types.SendTxArgs{To: &to, Data: append(erc20TransferSelector, 0x00)}))
+	require.Equal(t, CallTypeERC20Approve, ClassifyCall(_types.SendTxArgs{To: &to, Input: erc20ApproveSelector}))
+	require.Equal(t, CallTypeContractCall, ClassifyCall(_types.SendTxArgs{To: &to, Data: []byte{0xde, 0xad, 0xbe, 0xef}}))
+}
+
+func TestGasLimitPolicyApply(t *testing.T) {
+	policy := GasLimitPolicy{Multiplier: 1.5, Headroom: 1000}
+	require.Equal(t, params.TxGas, policy.Apply(CallTypeTransfer, params.TxGas))
+	require.Equal(t, uint64(151000), policy.Apply(CallTypeContractCall, 100000))
+	require.Equal(t, uint64(100000), GasLimitPolicy{}.Apply(CallTypeContractCall, 100000))
+}
+
+func TestTransactorGasLimit(t *testing.T) {
+	api := newFakeEthAPI(1)
+	transactor := NewTransactor(newFakeClient(api), big.NewInt(1), nil)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	ctx := context.Background()
+
+	build := func(data []byte) (uint64, error) {
+		tx, err := transactor.validateAndBuildTransaction(ctx, big.NewInt(1), _types.SendTxArgs{To: &to, Data: data}, -1)
+		if err != nil {
+			return 0, err
+		}
+		return tx.Gas(), nil
+	}
+
+	api.gasEstimate = 50000
+	gas, err := build(append(erc20TransferSelector, make([]byte, 64)...))
+	require.NoError(t, err)
+	require.Equal(t, uint64(60000), gas)
+
+	api.gasLimit = 55000
+	gas, err = build([]byte{0x01, 0x02, 0x03, 0x04})
+	require.NoError(t, err)
+	require.Equal(t, uint64(55000), gas)
+
+	api.gasLimit = 30_000_000
+	api.estimateErr = errors.New("upstream timeout")
+	gas, err = build(append(erc20TransferSelector, make([]byte, 64)...))
+	require.NoError(t, err)
+	require.Equal(t, uint64(ERC20TransferGas), gas)
+
+	gas, err = build(nil)
+	require.NoError(t, err)
+	require.Equal(t, params.TxGas, gas)
+
+	// there's no safe default for arbitrary contract calls
+	_, err = build([]byte{0x01, 0x02, 0x03, 0x04})
+	require.ErrorContains(t, err, "upstream timeout")
+
+	api.estimateErr = errors.New("execution reverted: ERC20: transfer amount exceeds balance")
+	_, err = build(append(erc20TransferSelector, make([]byte, 64)...))
+	require.ErrorContains(t, err, "execution reverted")
+}
+
+// rpcError is an error a node returns with a code and data.
+type rpcError struct {
+	code int
+	data interface{}
+}
+
+func (e rpcError) Error() string          { return "call failed" }
+func (e rpcError) ErrorCode() int         { return e.code }
+func (e rpcError) ErrorData() interface{} { return e.data }
+
+func TestIsRevert(t *testing.T) {
+	api := newFakeEthAPI(1)
+	client := newFakeClient(api)
+
+	testCases := []struct {
+		err    error
+		revert bool
+	}{
+		{errors.New("execution reverted: ERC20: transfer amount exceeds balance"), true},
+		{rpcError{code: 3, data: "0x08c379a0"}, true},
+		{rpcError{code: -32000, data: "0x"}, true},
+		{rpcError{code: 3}, true},
+		{errors.New("upstream timeout"), false},
+		{rpcError{code: -32603}, false},
+	}
+	for _, tc := range testCases {
+		api.estimateErr = tc.err
+		_, err := client.EstimateGas(context.Background(), ethereum.CallMsg{})
+		require.Error(t, err)
+		require.Equal(t, tc.revert, IsRevert(err), tc.err.Error())
+	}
+
+	require.False(t, IsRevert(nil))
+	require.False(t, IsRevert(context.DeadlineExceeded))
+}
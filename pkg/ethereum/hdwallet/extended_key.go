@@ -0,0 +1,315 @@
+package hdwallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// HardenedOffset is added to a child index to derive a hardened child.
+const HardenedOffset uint32 = 0x80000000
+
+const (
+	serializedKeyLen = 78
+	minSeedLen       = 16
+	maxSeedLen       = 64
+)
+
+var (
+	xprvVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+
+	masterHMACKey = []byte("Bitcoin seed")
+)
+
+var (
+	ErrInvalidSeed        = errors.New("seed must be between 16 and 64 bytes")
+	ErrInvalidKey         = errors.New("invalid extended key")
+	ErrInvalidChild       = errors.New("child index derives an invalid key")
+	ErrHardenedFromPublic = errors.New("can't derive a hardened child from a public key")
+	ErrNotPrivate         = errors.New("extended key is public")
+)
+
+// ExtendedKey is a BIP-32 extended private or public key on secp256k1.
+type ExtendedKey struct {
+	depth       uint8
+	parentFP    [4]byte
+	childNumber uint32
+	chainCode   []byte
+	// key is the 32-byte private scalar, or the 33-byte compressed public key
+	key       []byte
+	isPrivate bool
+
+	pubKey []byte
+}
+
+// NewMaster derives the master key of a BIP-32 tree from seed.
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, ErrInvalidSeed
+	}
+
+	mac := hmac.New(sha512.New, masterHMACKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(curveOrder()) >= 0 {
+		return nil, ErrInvalidSeed
+	}
+	return &ExtendedKey{
+		chainCode: sum[32:],
+		key:       sum[:32],
+		isPrivate: true,
+	}, nil
+}
+
+func curveOrder() *big.Int {
+	return crypto.S256().Params().N
+}
+
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.isPrivate
+}
+
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// publicKeyBytes returns the compressed public key.
+func (k *ExtendedKey) publicKeyBytes() []byte {
+	if !k.isPrivate {
+		return k.key
+	}
+	if k.pubKey == nil {
+		x, y := crypto.S256().ScalarBaseMult(k.key)
+		k.pubKey = crypto.CompressPubkey(&ecdsa.PublicKey{Curve: crypto.S256(), X: x, Y: y})
+	}
+	return k.pubKey
+}
+
+// Child derives the child key at index. Indexes from HardenedOffset on derive
+// hardened children, which requires a private key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedOffset
+	if hardened && !k.isPrivate {
+		return nil, ErrHardenedFromPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.publicKeyBytes()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	clear(data)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curveOrder()) >= 0 {
+		return nil, ErrInvalidChild
+	}
+
+	child := &ExtendedKey{
+		depth:       k.depth + 1,
+		childNumber: index,
+		chainCode:   sum[32:],
+		isPrivate:   k.isPrivate,
+	}
+	copy(child.parentFP[:], hash160(k.publicKeyBytes())[:4])
+
+	if k.isPrivate {
+		childKey := il.Add(il, new(big.Int).SetBytes(k.key))
+		childKey.Mod(childKey, curveOrder())
+		if childKey.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+		child.key = common.LeftPadBytes(childKey.Bytes(), 32)
+		return child, nil
+	}
+
+	parent, err := crypto.DecompressPubkey(k.key)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	curve := crypto.S256()
+	ilX, ilY := curve.ScalarBaseMult(sum[:32])
+	x, y := curve.Add(ilX, ilY, parent.X, parent.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	child.key = crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	return child, nil
+}
+
+// Derive walks path from this key. The path is relative, so it must start at
+// the depth of k; for a master key that is the full BIP-44 path. The result
+// never shares memory with k, so it can be zeroed independently.
+func (k *ExtendedKey) Derive(path accounts.DerivationPath) (*ExtendedKey, error) {
+	if len(path) == 0 {
+		return k.clone(), nil
+	}
+	key := k
+	for _, index := range path {
+		child, err := key.Child(index)
+		if key != k {
+			key.Zero()
+		}
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+func (k *ExtendedKey) clone() *ExtendedKey {
+	c := *k
+	c.chainCode = append([]byte(nil), k.chainCode...)
+	c.key = append([]byte(nil), k.key...)
+	return &c
+}
+
+// Neuter returns the extended public key of k.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.isPrivate {
+		return k
+	}
+	return &ExtendedKey{
+		depth:       k.depth,
+		parentFP:    k.parentFP,
+		childNumber: k.childNumber,
+		chainCode:   append([]byte(nil), k.chainCode...),
+		key:         append([]byte(nil), k.publicKeyBytes()...),
+	}
+}
+
+// ECDSA returns the private key of k.
+func (k *ExtendedKey) ECDSA() (*ecdsa.PrivateKey, error) {
+	if !k.isPrivate {
+		return nil, ErrNotPrivate
+	}
+	return crypto.ToECDSA(k.key)
+}
+
+// PublicKey returns the public key of k.
+func (k *ExtendedKey) PublicKey() (*ecdsa.PublicKey, error) {
+	return crypto.DecompressPubkey(k.publicKeyBytes())
+}
+
+// Address returns the Ethereum address of k.
+func (k *ExtendedKey) Address() (common.Address, error) {
+	pub, err := k.PublicKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Zero overwrites the private key material of k.
+func (k *ExtendedKey) Zero() {
+	if k.isPrivate {
+		clear(k.key)
+	}
+	clear(k.chainCode)
+}
+
+// String serializes k as a base58check xprv or xpub.
+func (k *ExtendedKey) String() string {
+	buf := make([]byte, 0, serializedKeyLen+4)
+	if k.isPrivate {
+		buf = append(buf, xprvVersion...)
+	} else {
+		buf = append(buf, xpubVersion...)
+	}
+	buf = append(buf, k.depth)
+	buf = append(buf, k.parentFP[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, k.childNumber)
+	buf = append(buf, k.chainCode...)
+	if k.isPrivate {
+		buf = append(buf, 0x00)
+	}
+	buf = append(buf, k.key...)
+	buf = append(buf, doubleSHA256(buf)[:4]...)
+	return base58Encode(buf)
+}
+
+// ParseExtendedKey decodes a base58check xprv or xpub.
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	buf, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) != serializedKeyLen+4 {
+		return nil, errors.Wrapf(ErrInvalidKey, "expected %d bytes, got %d", serializedKeyLen+4, len(buf))
+	}
+	payload, checksum := buf[:serializedKeyLen], buf[serializedKeyLen:]
+	if !bytes.Equal(doubleSHA256(payload)[:4], checksum) {
+		return nil, errors.Wrap(ErrInvalidKey, "bad checksum")
+	}
+
+	k := &ExtendedKey{
+		depth:       payload[4],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   append([]byte(nil), payload[13:45]...),
+	}
+	copy(k.parentFP[:], payload[5:9])
+	keyData := payload[45:]
+
+	switch version := payload[:4]; {
+	case bytes.Equal(version, xprvVersion):
+		if keyData[0] != 0x00 {
+			return nil, errors.Wrap(ErrInvalidKey, "bad private key prefix")
+		}
+		scalar := new(big.Int).SetBytes(keyData[1:])
+		if scalar.Sign() == 0 || scalar.Cmp(curveOrder()) >= 0 {
+			return nil, errors.Wrap(ErrInvalidKey, "private key out of range")
+		}
+		k.key = append([]byte(nil), keyData[1:]...)
+		k.isPrivate = true
+	case bytes.Equal(version, xpubVersion):
+		if _, err := crypto.DecompressPubkey(keyData); err != nil {
+			return nil, errors.Wrap(ErrInvalidKey, err.Error())
+		}
+		k.key = append([]byte(nil), keyData...)
+	default:
+		return nil, errors.Wrapf(ErrInvalidKey, "unknown version %x", version)
+	}
+
+	if k.depth == 0 && (k.parentFP != [4]byte{} || k.childNumber != 0) {
+		return nil, errors.Wrap(ErrInvalidKey, "master key with a parent")
+	}
+	return k, nil
+}
+
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
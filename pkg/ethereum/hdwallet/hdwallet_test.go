@@ -0,0 +1,167 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sss"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	abandonMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	junkMnemonic    = "test test test test test test test test test test test junk"
+)
+
+func TestMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic(256)
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), 24)
+	require.NoError(t, ValidateMnemonic(mnemonic))
+
+	require.NoError(t, ValidateMnemonic("  Abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ABOUT "))
+	require.ErrorIs(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"), ErrInvalidMnemonic)
+	require.ErrorIs(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon foo"), ErrInvalidMnemonic)
+
+	_, err = NewMnemonic(100)
+	require.Error(t, err)
+
+	// BIP-39 reference vector with passphrase "TREZOR"
+	seed, err := NewSeed(abandonMnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+}
+
+// BIP-32 test vector 1.
+func TestBIP32Vector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed)
+	require.NoError(t, err)
+	require.Equal(t, "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", master.String())
+	require.Equal(t, "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", master.Neuter().String())
+
+	path, err := accounts.ParseDerivationPath("m/0'/1")
+	require.NoError(t, err)
+	key, err := master.Derive(path)
+	require.NoError(t, err)
+	require.Equal(t, "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs", key.String())
+	require.Equal(t, "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ", key.Neuter().String())
+
+	// public derivation of the non-hardened step matches private derivation
+	hardened, err := master.Child(HardenedOffset)
+	require.NoError(t, err)
+	public, err := hardened.Neuter().Child(1)
+	require.NoError(t, err)
+	require.Equal(t, key.Neuter().String(), public.String())
+
+	_, err = hardened.Neuter().Child(HardenedOffset)
+	require.ErrorIs(t, err, ErrHardenedFromPublic)
+
+	parsed, err := ParseExtendedKey(key.String())
+	require.NoError(t, err)
+	require.Equal(t, key.String(), parsed.String())
+
+	corrupted := []byte(key.String())
+	corrupted[len(corrupted)-1] = '1'
+	_, err = ParseExtendedKey(string(corrupted))
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestWallet(t *testing.T) {
+	testCases := []struct {
+		mnemonic string
+		index    uint32
+		address  common.Address
+	}{
+		{abandonMnemonic, 0, common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")},
+		{junkMnemonic, 0, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")},
+		{junkMnemonic, 1, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")},
+	}
+
+	for _, tc := range testCases {
+		wallet, err := NewWallet(tc.mnemonic, "")
+		require.NoError(t, err)
+
+		signer, err := wallet.Signer(tc.index)
+		require.NoError(t, err)
+		require.Equal(t, tc.address, signer.Address())
+		require.Equal(t, fmt.Sprintf("m/44'/60'/0'/0/%d", tc.index), signer.Path().String())
+
+		hash := crypto.Keccak256([]byte("hello"))
+		sig, err := signer.Sign(hash)
+		require.NoError(t, err)
+		pub, err := crypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		require.Equal(t, tc.address, crypto.PubkeyToAddress(*pub))
+	}
+
+	_, err := NewWallet("abandon abandon", "")
+	require.ErrorIs(t, err, ErrInvalidMnemonic)
+}
+
+func TestMnemonicShares(t *testing.T) {
+	shares, err := SplitMnemonic(junkMnemonic, 3, 2)
+	require.NoError(t, err)
+
+	for _, subset := range [][]sss.Share{shares[:2], shares[1:], {shares[2], shares[0]}} {
+		mnemonic, err := CombineMnemonic(subset)
+		require.NoError(t, err)
+		require.Equal(t, junkMnemonic, mnemonic)
+	}
+
+	parsed, err := sss.ParseShare(shares[1].Words())
+	require.NoError(t, err)
+	wallet, err := NewWalletFromShares([]sss.Share{shares[0], parsed}, "")
+	require.NoError(t, err)
+	signer, err := wallet.Signer(0)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), signer.Address())
+
+	_, err = SplitMnemonic("abandon abandon", 3, 2)
+	require.ErrorIs(t, err, ErrInvalidMnemonic)
+	_, err = CombineMnemonic(shares[:1])
+	require.ErrorIs(t, err, sss.ErrNotEnoughShares)
+}
+
+func TestWatchOnlyWallet(t *testing.T) {
+	wallet, err := NewWallet(junkMnemonic, "")
+	require.NoError(t, err)
+
+	xpub, err := wallet.ExtendedPublicKey(DefaultRootPath)
+	require.NoError(t, err)
+
+	watchOnly, err := NewWatchOnlyWallet(xpub)
+	require.NoError(t, err)
+
+	address, err := watchOnly.Address(1)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), address)
+
+	expected, err := wallet.Accounts(0, 5)
+	require.NoError(t, err)
+	derived, err := watchOnly.Accounts(0, 5)
+	require.NoError(t, err)
+	for i := range expected {
+		require.Equal(t, expected[i].Address, derived[i].Address)
+		require.Equal(t, accounts.DerivationPath{uint32(i)}, derived[i].Path)
+	}
+
+	_, err = watchOnly.Derive(accounts.DerivationPath{HardenedOffset})
+	require.ErrorIs(t, err, ErrHardenedFromPublic)
+
+	derived, err = watchOnly.Accounts(HardenedOffset-2, 2)
+	require.NoError(t, err)
+	require.Len(t, derived, 2)
+	for _, r := range [][2]uint32{{0, MaxAccounts + 1}, {HardenedOffset - 1, 2}, {math.MaxUint32, 2}} {
+		_, err = watchOnly.Accounts(r[0], r[1])
+		require.ErrorIs(t, err, ErrInvalidRange)
+		_, err = wallet.Accounts(r[0], r[1])
+		require.ErrorIs(t, err, ErrInvalidRange)
+	}
+}
@@ -0,0 +1,43 @@
+package hdwallet
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic generates a BIP-39 mnemonic from bits of entropy. bits must be a
+// multiple of 32 between 128 (12 words) and 256 (24 words).
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	defer clear(entropy)
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic checks the words and the checksum of an English mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	if _, err := bip39.EntropyFromMnemonic(normalizeMnemonic(mnemonic)); err != nil {
+		return errors.Wrap(ErrInvalidMnemonic, err.Error())
+	}
+	return nil
+}
+
+// NewSeed validates mnemonic and derives the 64-byte BIP-39 seed protected by
+// passphrase. An empty passphrase is valid.
+func NewSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	return bip39.NewSeed(normalizeMnemonic(mnemonic), passphrase), nil
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
@@ -0,0 +1,44 @@
+package hdwallet
+
+import (
+	"github.com/openweb3-io/anychain/pkg/ethereum/sss"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// SplitMnemonic splits the entropy of mnemonic into n Shamir shares, any
+// threshold of which restore it with CombineMnemonic. The BIP-39 passphrase,
+// if any, is not part of the shares.
+func SplitMnemonic(mnemonic string, n, threshold int) ([]sss.Share, error) {
+	entropy, err := bip39.EntropyFromMnemonic(normalizeMnemonic(mnemonic))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidMnemonic, err.Error())
+	}
+	defer clear(entropy)
+
+	return sss.Split(entropy, n, threshold)
+}
+
+// CombineMnemonic restores a mnemonic split by SplitMnemonic.
+func CombineMnemonic(shares []sss.Share) (string, error) {
+	entropy, err := sss.Combine(shares)
+	if err != nil {
+		return "", err
+	}
+	defer clear(entropy)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidMnemonic, err.Error())
+	}
+	return mnemonic, nil
+}
+
+// NewWalletFromShares restores a wallet from mnemonic shares and passphrase.
+func NewWalletFromShares(shares []sss.Share, passphrase string) (*Wallet, error) {
+	mnemonic, err := CombineMnemonic(shares)
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(mnemonic, passphrase)
+}
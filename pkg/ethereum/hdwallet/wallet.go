@@ -0,0 +1,199 @@
+package hdwallet
+
+import (
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+)
+
+// MaxAccounts is the most accounts Accounts lists at once.
+const MaxAccounts = 1000
+
+// ErrInvalidRange is returned by Accounts for ranges that are too long or
+// reach the hardened indexes.
+var ErrInvalidRange = errors.New("invalid account range")
+
+// DefaultRootPath is the BIP-44 external chain of the first Ethereum account,
+// m/44'/60'/0'/0. Account i lives at DefaultRootPath/i.
+var DefaultRootPath = accounts.DefaultRootDerivationPath
+
+type Account struct {
+	Address common.Address
+	Path    accounts.DerivationPath
+}
+
+// Signer is a PrivateKeySigner for a key derived from a Wallet.
+type Signer struct {
+	*ethereum.PrivateKeySigner
+	path accounts.DerivationPath
+}
+
+var _ ethereum.Signer = (*Signer)(nil)
+
+func (s *Signer) Path() accounts.DerivationPath {
+	return s.path
+}
+
+// Wallet derives signers from a BIP-39 seed.
+type Wallet struct {
+	master *ExtendedKey
+}
+
+// NewWallet creates a wallet from a BIP-39 mnemonic and an optional passphrase.
+func NewWallet(mnemonic string, passphrase string) (*Wallet, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+
+	return NewWalletFromSeed(seed)
+}
+
+func NewWalletFromSeed(seed []byte) (*Wallet, error) {
+	master, err := NewMaster(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{master: master}, nil
+}
+
+// Derive returns the signer of an absolute path such as m/44'/60'/0'/0/7.
+func (w *Wallet) Derive(path accounts.DerivationPath) (*Signer, error) {
+	key, err := w.master.Derive(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "derive %s", path)
+	}
+	defer key.Zero()
+
+	privateKey, err := key.ECDSA()
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		PrivateKeySigner: ethereum.NewPrivateKeySigner(privateKey),
+		path:             append(accounts.DerivationPath(nil), path...),
+	}, nil
+}
+
+// Signer returns the signer of account index under DefaultRootPath.
+func (w *Wallet) Signer(index uint32) (*Signer, error) {
+	return w.Derive(accountPath(index))
+}
+
+// Accounts lists count accounts under DefaultRootPath starting at index start.
+// count is at most MaxAccounts and the range must stay below HardenedOffset.
+func (w *Wallet) Accounts(start, count uint32) ([]Account, error) {
+	if err := checkRange(start, count); err != nil {
+		return nil, err
+	}
+	root, err := w.master.Derive(DefaultRootPath)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Zero()
+
+	return deriveAccounts(root, DefaultRootPath, start, count)
+}
+
+// ExtendedPublicKey returns the xpub of path, e.g. DefaultRootPath to hand out
+// to a watch-only wallet.
+func (w *Wallet) ExtendedPublicKey(path accounts.DerivationPath) (string, error) {
+	key, err := w.master.Derive(path)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+
+	return key.Neuter().String(), nil
+}
+
+// WatchOnlyWallet derives addresses from an extended public key, so deposit
+// addresses can be generated on hosts that never see a private key. Only
+// non-hardened children can be derived.
+type WatchOnlyWallet struct {
+	key *ExtendedKey
+}
+
+// NewWatchOnlyWallet parses an xpub, typically of DefaultRootPath. An xprv is
+// accepted but only its public half is kept.
+func NewWatchOnlyWallet(xpub string) (*WatchOnlyWallet, error) {
+	key, err := ParseExtendedKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsPrivate() {
+		neutered := key.Neuter()
+		key.Zero()
+		key = neutered
+	}
+	return &WatchOnlyWallet{key: key}, nil
+}
+
+// Address returns the address of child index of the xpub.
+func (w *WatchOnlyWallet) Address(index uint32) (common.Address, error) {
+	child, err := w.key.Child(index)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return child.Address()
+}
+
+// Derive returns the address of a path relative to the xpub.
+func (w *WatchOnlyWallet) Derive(path accounts.DerivationPath) (common.Address, error) {
+	key, err := w.key.Derive(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return key.Address()
+}
+
+// Accounts lists count child addresses starting at index start. Paths are
+// relative to the xpub. The range is checked like for Wallet.Accounts.
+func (w *WatchOnlyWallet) Accounts(start, count uint32) ([]Account, error) {
+	if err := checkRange(start, count); err != nil {
+		return nil, err
+	}
+	return deriveAccounts(w.key, nil, start, count)
+}
+
+// checkRange bounds count and keeps start+count from wrapping around or
+// crossing into the hardened indexes.
+func checkRange(start, count uint32) error {
+	if count > MaxAccounts {
+		return errors.Wrapf(ErrInvalidRange, "%d accounts, at most %d", count, MaxAccounts)
+	}
+	if uint64(start)+uint64(count) > uint64(HardenedOffset) {
+		return errors.Wrapf(ErrInvalidRange, "%d accounts from %d reach hardened indexes", count, start)
+	}
+	return nil
+}
+
+func deriveAccounts(parent *ExtendedKey, parentPath accounts.DerivationPath, start, count uint32) ([]Account, error) {
+	accs := make([]Account, 0, count)
+	for index := start; index < start+count; index++ {
+		child, err := parent.Child(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "derive child %d", index)
+		}
+		address, err := child.Address()
+		child.Zero()
+		if err != nil {
+			return nil, err
+		}
+
+		accs = append(accs, Account{Address: address, Path: childPath(parentPath, index)})
+	}
+	return accs, nil
+}
+
+func accountPath(index uint32) accounts.DerivationPath {
+	return childPath(DefaultRootPath, index)
+}
+
+func childPath(parent accounts.DerivationPath, index uint32) accounts.DerivationPath {
+	path := make(accounts.DerivationPath, len(parent), len(parent)+1)
+	copy(path, parent)
+	return append(path, index)
+}
@@ -0,0 +1,215 @@
+// Package ethtest provides an in-process node for the tests of the contract
+// clients.
+package ethtest
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// ChainID is the chain id of every Chain.
+var ChainID = big.NewInt(1)
+
+// Gas is the estimate of calls that don't revert.
+const Gas = 100000
+
+// Contract is the single contract a Chain runs.
+type Contract interface {
+	// Run executes a call of sender and returns its return data and events.
+	Run(sender common.Address, input []byte) ([]byte, []*types.Log, error)
+	// Snapshot returns a copy of the state of the contract.
+	Snapshot() interface{}
+	// Revert restores a state returned by Snapshot.
+	Revert(snapshot interface{})
+}
+
+// Chain is an in-process node running a single contract. Calls, estimates and
+// simulations run on a snapshot of the contract and are reverted, sent
+// transactions are applied and mined immediately.
+type Chain struct {
+	mu       sync.Mutex
+	contract Contract
+	nonces   map[common.Address]uint64
+	receipts map[common.Hash]*types.Receipt
+	// MineOnReceipt keeps sent transactions pending until a receipt is asked
+	// for, so that calls and estimates don't see their effects before.
+	MineOnReceipt bool
+	pending       []pendingTx
+}
+
+type pendingTx struct {
+	tx     *types.Transaction
+	sender common.Address
+}
+
+// NewChain returns a chain running contract.
+func NewChain(contract Contract) *Chain {
+	return &Chain{
+		contract: contract,
+		nonces:   make(map[common.Address]uint64),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+// Dial serves chain in-process as the eth namespace and returns a client of
+// it, stopped when the test finishes.
+func Dial(t *testing.T, chain *Chain) *ethclient.Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", chain))
+	t.Cleanup(server.Stop)
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func (c *Chain) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(ChainID)
+}
+
+func (c *Chain) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1_000_000_000))
+}
+
+// EstimateGas fails like a node for calls that revert on the latest state.
+func (c *Chain) EstimateGas(args map[string]interface{}) (hexutil.Uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.call(args); err != nil {
+		return 0, err
+	}
+	return Gas, nil
+}
+
+func (c *Chain) GetTransactionCount(address common.Address, block string) hexutil.Uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return hexutil.Uint64(c.nonces[address])
+}
+
+func (c *Chain) GetBlockByNumber(number string, full bool) *types.Header {
+	return &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: common.Big0}
+}
+
+func (c *Chain) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.call(args)
+}
+
+// call runs a call without keeping its state changes. c.mu must be held.
+func (c *Chain) call(args map[string]interface{}) (hexutil.Bytes, error) {
+	var from common.Address
+	if s, ok := args["from"].(string); ok {
+		from = common.HexToAddress(s)
+	}
+	snapshot := c.contract.Snapshot()
+	defer c.contract.Revert(snapshot)
+	ret, _, err := c.contract.Run(from, hexutil.MustDecode(args["input"].(string)))
+	return ret, err
+}
+
+// SimulateOpts are the eth_simulateV1 options the chain understands.
+type SimulateOpts struct {
+	BlockStateCalls []struct {
+		Calls []struct {
+			From  common.Address `json:"from"`
+			Input hexutil.Bytes  `json:"input"`
+		} `json:"calls"`
+	} `json:"blockStateCalls"`
+}
+
+// SimulateCallResult is the outcome of a simulated call.
+type SimulateCallResult struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Status     hexutil.Uint64 `json:"status"`
+}
+
+// SimulateBlockResult is the outcome of a simulated block.
+type SimulateBlockResult struct {
+	Calls []SimulateCallResult `json:"calls"`
+}
+
+// SimulateV1 runs the calls of the first block one after the other and
+// reverts them all.
+func (c *Chain) SimulateV1(opts SimulateOpts, block string) ([]SimulateBlockResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := c.contract.Snapshot()
+	defer c.contract.Revert(snapshot)
+	var results []SimulateCallResult
+	for _, call := range opts.BlockStateCalls[0].Calls {
+		ret, _, err := c.contract.Run(call.From, call.Input)
+		result := SimulateCallResult{ReturnData: ret, Status: hexutil.Uint64(types.ReceiptStatusSuccessful)}
+		if err != nil {
+			result.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+		}
+		results = append(results, result)
+	}
+	return []SimulateBlockResult{{Calls: results}}, nil
+}
+
+func (c *Chain) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(ChainID), tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nonces[sender]++
+	if c.MineOnReceipt {
+		c.pending = append(c.pending, pendingTx{tx: tx, sender: sender})
+	} else {
+		c.mine(tx, sender)
+	}
+	return tx.Hash(), nil
+}
+
+// mine applies tx and stores its receipt. Failed transactions leave the
+// contract untouched. c.mu must be held.
+func (c *Chain) mine(tx *types.Transaction, sender common.Address) {
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		TxHash:            tx.Hash(),
+		GasUsed:           tx.Gas(),
+		CumulativeGasUsed: tx.Gas(),
+		BlockNumber:       big.NewInt(1),
+		Logs:              []*types.Log{},
+	}
+	snapshot := c.contract.Snapshot()
+	if _, logs, err := c.contract.Run(sender, tx.Data()); err == nil {
+		for i, log := range logs {
+			log.TxHash = tx.Hash()
+			log.Index = uint(i)
+			receipt.Logs = append(receipt.Logs, log)
+		}
+	} else {
+		c.contract.Revert(snapshot)
+		receipt.Status = types.ReceiptStatusFailed
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	c.receipts[tx.Hash()] = receipt
+}
+
+func (c *Chain) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.pending {
+		c.mine(p.tx, p.sender)
+	}
+	c.pending = nil
+	return c.receipts[hash]
+}
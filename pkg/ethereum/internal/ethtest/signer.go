@@ -0,0 +1,17 @@
+package ethtest
+
+import (
+	"errors"
+
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+)
+
+// TypedDataSigner refuses to sign raw hashes, like Clef, so that only the
+// hashing methods of the Signer work.
+type TypedDataSigner struct {
+	*ethereum.PrivateKeySigner
+}
+
+func (TypedDataSigner) Sign(hash []byte) ([]byte, error) {
+	return nil, errors.New("raw hashes aren't signed")
+}
@@ -0,0 +1,299 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	version = 3
+
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	cipherAES128CTR = "aes-128-ctr"
+	prfHMACSHA256   = "hmac-sha256"
+	derivedKeyLen   = 32
+)
+
+var (
+	ErrDecrypt         = errors.New("could not decrypt key with given password")
+	ErrUnsupported     = errors.New("unsupported keystore format")
+	ErrAddressMismatch = errors.New("key doesn't match the keystore address")
+)
+
+// KDFParams selects the key derivation function used to encrypt a key.
+type KDFParams struct {
+	KDF string
+
+	ScryptN int
+	ScryptR int
+	ScryptP int
+
+	PBKDF2Iterations int
+}
+
+var (
+	// StandardScrypt matches geth's default, ~1s and 256MB on a modern CPU.
+	StandardScrypt = KDFParams{KDF: KDFScrypt, ScryptN: 1 << 18, ScryptR: 8, ScryptP: 1}
+	// LightScrypt trades security for speed, e.g. for tests or mobile devices.
+	LightScrypt = KDFParams{KDF: KDFScrypt, ScryptN: 1 << 12, ScryptR: 8, ScryptP: 6}
+	// StandardPBKDF2 is the pbkdf2 setting of the Web3 Secret Storage spec.
+	StandardPBKDF2 = KDFParams{KDF: KDFPBKDF2, PBKDF2Iterations: 262144}
+
+	// MaxScrypt bounds the scrypt work DecryptKey accepts, so a crafted keystore
+	// can't make it allocate gigabytes or spin for minutes. Memory is bounded by
+	// N·r and time by N·r·p, which lets the spec's N=2^18, r=1, p=8 through.
+	MaxScrypt = StandardScrypt
+)
+
+// encryptedKeyJSON is the Web3 Secret Storage V3 layout, see
+// https://ethereum.org/en/developers/docs/data-structures-and-encoding/web3-secret-storage/
+type encryptedKeyJSON struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParamsJSON       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// EncryptKey encrypts key with password into a V3 keystore JSON.
+func EncryptKey(key *ecdsa.PrivateKey, password string, params KDFParams) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	kdfParams := map[string]interface{}{
+		"dklen": derivedKeyLen,
+		"salt":  hex.EncodeToString(salt),
+	}
+	var derivedKey []byte
+	switch params.KDF {
+	case KDFScrypt:
+		var err error
+		derivedKey, err = scrypt.Key([]byte(password), salt, params.ScryptN, params.ScryptR, params.ScryptP, derivedKeyLen)
+		if err != nil {
+			return nil, err
+		}
+		kdfParams["n"] = params.ScryptN
+		kdfParams["r"] = params.ScryptR
+		kdfParams["p"] = params.ScryptP
+	case KDFPBKDF2:
+		if params.PBKDF2Iterations <= 0 {
+			return nil, errors.Wrap(ErrUnsupported, "pbkdf2 iterations must be positive")
+		}
+		derivedKey = pbkdf2.Key([]byte(password), salt, params.PBKDF2Iterations, derivedKeyLen, sha256.New)
+		kdfParams["c"] = params.PBKDF2Iterations
+		kdfParams["prf"] = prfHMACSHA256
+	default:
+		return nil, errors.Wrapf(ErrUnsupported, "kdf %q", params.KDF)
+	}
+	defer zeroBytes(derivedKey)
+
+	plainText := crypto.FromECDSA(key)
+	defer zeroBytes(plainText)
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], plainText, iv)
+	if err != nil {
+		return nil, err
+	}
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	return json.Marshal(encryptedKeyJSON{
+		Address: hex.EncodeToString(crypto.PubkeyToAddress(key.PublicKey).Bytes()),
+		Crypto: cryptoJSON{
+			Cipher:       cipherAES128CTR,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          params.KDF,
+			KDFParams:    kdfParams,
+			MAC:          hex.EncodeToString(mac),
+		},
+		ID:      uuid.NewString(),
+		Version: version,
+	})
+}
+
+// DecryptKey decrypts a V3 keystore JSON encrypted with scrypt or pbkdf2.
+func DecryptKey(keyJSON []byte, password string) (*ecdsa.PrivateKey, error) {
+	var k encryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &k); err != nil {
+		return nil, errors.Wrap(ErrUnsupported, err.Error())
+	}
+	if k.Version != version {
+		return nil, errors.Wrapf(ErrUnsupported, "version %d", k.Version)
+	}
+	if k.Crypto.Cipher != cipherAES128CTR {
+		return nil, errors.Wrapf(ErrUnsupported, "cipher %q", k.Crypto.Cipher)
+	}
+
+	mac, err := hex.DecodeString(k.Crypto.MAC)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupported, "invalid mac")
+	}
+	iv, err := hex.DecodeString(k.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupported, "invalid iv")
+	}
+	cipherText, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupported, "invalid ciphertext")
+	}
+
+	derivedKey, err := deriveKey(k.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(derivedKey)
+
+	calculatedMAC := crypto.Keccak256(derivedKey[16:32], cipherText)
+	if subtle.ConstantTimeCompare(calculatedMAC, mac) != 1 {
+		return nil, ErrDecrypt
+	}
+
+	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(plainText)
+
+	key, err := crypto.ToECDSA(plainText)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.Address != "" {
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		if !strings.EqualFold(strings.TrimPrefix(k.Address, "0x"), hex.EncodeToString(address.Bytes())) {
+			ZeroKey(key)
+			return nil, ErrAddressMismatch
+		}
+	}
+	return key, nil
+}
+
+// KeyAddress returns the address stored in a V3 keystore JSON without decrypting it.
+func KeyAddress(keyJSON []byte) (common.Address, error) {
+	var k struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &k); err != nil {
+		return common.Address{}, errors.Wrap(ErrUnsupported, err.Error())
+	}
+	if !common.IsHexAddress(k.Address) {
+		return common.Address{}, errors.Wrapf(ErrUnsupported, "invalid address %q", k.Address)
+	}
+	return common.HexToAddress(k.Address), nil
+}
+
+func deriveKey(c cryptoJSON, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(stringParam(c.KDFParams, "salt"))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupported, "invalid salt")
+	}
+	dkLen := intParam(c.KDFParams, "dklen")
+	if dkLen < derivedKeyLen {
+		return nil, errors.Wrapf(ErrUnsupported, "dklen %d", dkLen)
+	}
+
+	switch c.KDF {
+	case KDFScrypt:
+		n, r, p := intParam(c.KDFParams, "n"), intParam(c.KDFParams, "r"), intParam(c.KDFParams, "p")
+		if err := checkScryptParams(n, r, p); err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+	case KDFPBKDF2:
+		if prf := stringParam(c.KDFParams, "prf"); prf != prfHMACSHA256 {
+			return nil, errors.Wrapf(ErrUnsupported, "prf %q", prf)
+		}
+		iterations := intParam(c.KDFParams, "c")
+		if iterations <= 0 {
+			return nil, errors.Wrapf(ErrUnsupported, "pbkdf2 iterations %d", iterations)
+		}
+		return pbkdf2.Key([]byte(password), salt, iterations, dkLen, sha256.New), nil
+	}
+	return nil, errors.Wrapf(ErrUnsupported, "kdf %q", c.KDF)
+}
+
+func checkScryptParams(n, r, p int) error {
+	if n <= 0 || r <= 0 || p <= 0 {
+		return errors.Wrapf(ErrUnsupported, "scrypt n=%d r=%d p=%d", n, r, p)
+	}
+	maxMemory := MaxScrypt.ScryptN * MaxScrypt.ScryptR
+	maxWork := maxMemory * MaxScrypt.ScryptP
+	// divisions keep crafted values from overflowing
+	if n > maxMemory/r || p > maxWork/(n*r) {
+		return errors.Wrapf(ErrUnsupported, "scrypt n=%d r=%d p=%d exceeds the limit", n, r, p)
+	}
+	return nil
+}
+
+func intParam(params map[string]interface{}, name string) int {
+	if v, ok := params[name].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+func stringParam(params map[string]interface{}, name string) string {
+	if v, ok := params[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func zeroBytes(b []byte) {
+	clear(b)
+}
+
+// ZeroKey overwrites the private scalar of key in memory.
+func ZeroKey(key *ecdsa.PrivateKey) {
+	if key == nil || key.D == nil {
+		return
+	}
+	clear(key.D.Bits())
+	key.D.SetInt64(0)
+}
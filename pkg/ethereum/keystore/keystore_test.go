@@ -0,0 +1,176 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sss"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors of the Web3 Secret Storage spec.
+const (
+	testPassword = "testpassword"
+	testKey      = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+	scryptKeyJSON = `{
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+    "ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+    "kdf": "scrypt",
+    "kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+    "mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+  },
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "version": 3
+}`
+
+	pbkdf2KeyJSON = `{
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+    "ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+    "kdf": "pbkdf2",
+    "kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256", "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+    "mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+  },
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "version": 3
+}`
+)
+
+// testParams keeps the tests fast.
+var testParams = KDFParams{KDF: KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+
+func TestDecryptKeyVectors(t *testing.T) {
+	for name, keyJSON := range map[string]string{"scrypt": scryptKeyJSON, "pbkdf2": pbkdf2KeyJSON} {
+		t.Run(name, func(t *testing.T) {
+			key, err := DecryptKey([]byte(keyJSON), testPassword)
+			require.NoError(t, err)
+			require.Equal(t, testKey, hex.EncodeToString(crypto.FromECDSA(key)))
+
+			_, err = DecryptKey([]byte(keyJSON), "wrong")
+			require.ErrorIs(t, err, ErrDecrypt)
+		})
+	}
+}
+
+func TestEncryptDecryptKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	for _, params := range []KDFParams{testParams, {KDF: KDFPBKDF2, PBKDF2Iterations: 1024}} {
+		keyJSON, err := EncryptKey(key, "foo", params)
+		require.NoError(t, err)
+
+		address, err := KeyAddress(keyJSON)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), address)
+
+		decrypted, err := DecryptKey(keyJSON, "foo")
+		require.NoError(t, err)
+		require.Equal(t, key.D, decrypted.D)
+	}
+
+	_, err = EncryptKey(key, "foo", KDFParams{KDF: "argon2"})
+	require.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestDecryptKeyScryptLimit(t *testing.T) {
+	for _, params := range []string{
+		`"n": 524288, "r": 8, "p": 1`,
+		`"n": 262144, "r": 16, "p": 1`,
+		`"n": 262144, "r": 8, "p": 2`,
+		`"n": 2, "r": 1, "p": 9223372036854775807`,
+		`"n": 0, "r": 8, "p": 1`,
+	} {
+		keyJSON := strings.Replace(scryptKeyJSON, `"n": 262144, "r": 1, "p": 8`, params, 1)
+		_, err := DecryptKey([]byte(keyJSON), testPassword)
+		require.ErrorIs(t, err, ErrUnsupported, params)
+	}
+}
+
+func TestManager(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := NewManager(dir, testParams)
+	require.NoError(t, err)
+
+	account, err := manager.NewAccount("foo")
+	require.NoError(t, err)
+
+	info, err := os.Stat(account.Path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	accounts, err := manager.Accounts()
+	require.NoError(t, err)
+	require.Equal(t, []Account{account}, accounts)
+
+	signer, err := manager.Signer(account.Address)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, signer.Address())
+
+	hash := crypto.Keccak256([]byte("hello"))
+	_, err = signer.Sign(hash)
+	require.ErrorIs(t, err, ErrLocked)
+
+	require.ErrorIs(t, manager.Unlock(account.Address, "bar", 0), ErrDecrypt)
+	require.NoError(t, manager.Unlock(account.Address, "foo", 0))
+
+	sig, err := signer.Sign(hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, crypto.PubkeyToAddress(*pub))
+
+	tx, err := signer.SignTx(big.NewInt(1), types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21000}))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), tx)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, sender)
+
+	// the key is zeroed on lock
+	key := manager.unlocked[account.Address].key
+	manager.Lock(account.Address)
+	require.Zero(t, key.D.Sign())
+	_, err = signer.Sign(hash)
+	require.ErrorIs(t, err, ErrLocked)
+
+	require.NoError(t, manager.Unlock(account.Address, "foo", 50*time.Millisecond))
+	require.True(t, manager.IsUnlocked(account.Address))
+	require.Eventually(t, func() bool { return !manager.IsUnlocked(account.Address) }, time.Second, 10*time.Millisecond)
+
+	// export and import into another directory under a new password
+	keyJSON, err := manager.Export(account.Address, "foo", "baz")
+	require.NoError(t, err)
+	_, err = manager.Import(keyJSON, "baz", "foo")
+	require.ErrorIs(t, err, ErrAccountExists)
+
+	other, err := NewManager(t.TempDir(), testParams)
+	require.NoError(t, err)
+	imported, err := other.Import(keyJSON, "baz", "qux")
+	require.NoError(t, err)
+	require.Equal(t, account.Address, imported.Address)
+	require.NoError(t, other.Unlock(account.Address, "qux", 0))
+
+	// Shamir backup restored from two of three shares
+	shares, err := manager.ExportShares(account.Address, "foo", 3, 2)
+	require.NoError(t, err)
+	restored, err := NewManager(t.TempDir(), testParams)
+	require.NoError(t, err)
+	imported, err = restored.ImportShares([]sss.Share{shares[2], shares[0]}, "qux")
+	require.NoError(t, err)
+	require.Equal(t, account.Address, imported.Address)
+	require.NoError(t, restored.Unlock(account.Address, "qux", 0))
+
+	require.NoError(t, manager.Delete(account.Address, "foo"))
+	_, err = manager.Signer(account.Address)
+	require.ErrorIs(t, err, ErrNoAccount)
+}
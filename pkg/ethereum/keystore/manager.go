@@ -0,0 +1,296 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoAccount     = errors.New("no keystore for address")
+	ErrLocked        = errors.New("account is locked")
+	ErrAccountExists = errors.New("account already exists")
+)
+
+type Account struct {
+	Address common.Address
+	Path    string
+}
+
+type unlockedKey struct {
+	key   *ecdsa.PrivateKey
+	timer *time.Timer
+}
+
+// Manager manages a directory of V3 keystore files. Decrypted keys only live in
+// memory while their account is unlocked and are zeroed when it gets locked.
+type Manager struct {
+	dir    string
+	params KDFParams
+
+	mu       sync.Mutex
+	unlocked map[common.Address]*unlockedKey
+}
+
+// NewManager opens the keystore directory dir, creating it if needed. New and
+// re-encrypted keys use params.
+func NewManager(dir string, params KDFParams) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Manager{
+		dir:      dir,
+		params:   params,
+		unlocked: make(map[common.Address]*unlockedKey),
+	}, nil
+}
+
+// Accounts lists the keystore files of the directory, sorted by address.
+func (m *Manager) Accounts() ([]Account, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []Account
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(m.dir, entry.Name())
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		address, err := KeyAddress(keyJSON)
+		if err != nil {
+			// not a keystore file
+			continue
+		}
+		accounts = append(accounts, Account{Address: address, Path: path})
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Address.Cmp(accounts[j].Address) < 0
+	})
+	return accounts, nil
+}
+
+func (m *Manager) Find(address common.Address) (Account, error) {
+	accounts, err := m.Accounts()
+	if err != nil {
+		return Account{}, err
+	}
+	for _, account := range accounts {
+		if account.Address == address {
+			return account, nil
+		}
+	}
+	return Account{}, errors.Wrap(ErrNoAccount, address.Hex())
+}
+
+func (m *Manager) HasAddress(address common.Address) bool {
+	_, err := m.Find(address)
+	return err == nil
+}
+
+// NewAccount generates a key and stores it encrypted with password.
+func (m *Manager) NewAccount(password string) (Account, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return Account{}, err
+	}
+	defer ZeroKey(key)
+
+	return m.ImportECDSA(key, password)
+}
+
+// ImportECDSA stores key encrypted with password.
+func (m *Manager) ImportECDSA(key *ecdsa.PrivateKey, password string) (Account, error) {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	if m.HasAddress(address) {
+		return Account{}, errors.Wrap(ErrAccountExists, address.Hex())
+	}
+
+	keyJSON, err := EncryptKey(key, password, m.params)
+	if err != nil {
+		return Account{}, err
+	}
+
+	path := filepath.Join(m.dir, keyFileName(address))
+	if err := writeKeyFile(path, keyJSON); err != nil {
+		return Account{}, err
+	}
+	return Account{Address: address, Path: path}, nil
+}
+
+// Import decrypts keyJSON with password and stores it re-encrypted with newPassword.
+func (m *Manager) Import(keyJSON []byte, password string, newPassword string) (Account, error) {
+	key, err := DecryptKey(keyJSON, password)
+	if err != nil {
+		return Account{}, err
+	}
+	defer ZeroKey(key)
+
+	return m.ImportECDSA(key, newPassword)
+}
+
+// Export returns the key of address as keystore JSON encrypted with newPassword.
+func (m *Manager) Export(address common.Address, password string, newPassword string) ([]byte, error) {
+	key, err := m.decrypt(address, password)
+	if err != nil {
+		return nil, err
+	}
+	defer ZeroKey(key)
+
+	return EncryptKey(key, newPassword, m.params)
+}
+
+// Delete removes the keystore file of address after checking password.
+func (m *Manager) Delete(address common.Address, password string) error {
+	account, err := m.Find(address)
+	if err != nil {
+		return err
+	}
+	key, err := m.decrypt(address, password)
+	if err != nil {
+		return err
+	}
+	ZeroKey(key)
+
+	m.Lock(address)
+	return os.Remove(account.Path)
+}
+
+func (m *Manager) decrypt(address common.Address, password string) (*ecdsa.PrivateKey, error) {
+	account, err := m.Find(address)
+	if err != nil {
+		return nil, err
+	}
+	keyJSON, err := os.ReadFile(account.Path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKey(keyJSON, password)
+}
+
+// Unlock decrypts the key of address and keeps it in memory for timeout, or
+// until Lock is called if timeout is 0. Unlocking an unlocked account resets
+// its timeout.
+func (m *Manager) Unlock(address common.Address, password string, timeout time.Duration) error {
+	key, err := m.decrypt(address, password)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lockLocked(address)
+	u := &unlockedKey{key: key}
+	if timeout > 0 {
+		u.timer = time.AfterFunc(timeout, func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			// the key may have been re-unlocked in the meantime
+			if m.unlocked[address] == u {
+				m.lockLocked(address)
+			}
+		})
+	}
+	m.unlocked[address] = u
+	return nil
+}
+
+// Lock drops the decrypted key of address from memory.
+func (m *Manager) Lock(address common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lockLocked(address)
+}
+
+func (m *Manager) lockLocked(address common.Address) {
+	u, ok := m.unlocked[address]
+	if !ok {
+		return
+	}
+	if u.timer != nil {
+		u.timer.Stop()
+	}
+	ZeroKey(u.key)
+	delete(m.unlocked, address)
+}
+
+// LockAll locks every unlocked account.
+func (m *Manager) LockAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for address := range m.unlocked {
+		m.lockLocked(address)
+	}
+}
+
+func (m *Manager) IsUnlocked(address common.Address) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.unlocked[address]
+	return ok
+}
+
+// signHash signs with the unlocked key of address. The key never leaves the
+// manager's lock so it can't be zeroed mid-signature.
+func (m *Manager) signHash(address common.Address, hash []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.unlocked[address]
+	if !ok {
+		return nil, errors.Wrap(ErrLocked, address.Hex())
+	}
+	return crypto.Sign(hash, u.key)
+}
+
+// Signer returns a signer for address. The account must be unlocked when signing.
+func (m *Manager) Signer(address common.Address) (*Signer, error) {
+	if !m.HasAddress(address) {
+		return nil, errors.Wrap(ErrNoAccount, address.Hex())
+	}
+	return &Signer{manager: m, address: address}, nil
+}
+
+func keyFileName(address common.Address) string {
+	ts := time.Now().UTC()
+	return fmt.Sprintf("UTC--%s--%x", toISO8601(ts), address.Bytes())
+}
+
+func toISO8601(t time.Time) string {
+	return fmt.Sprintf("%04d-%02d-%02dT%02d-%02d-%02d.%09dZ",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+}
+
+// writeKeyFile writes the file atomically so a crash never leaves a truncated key.
+func writeKeyFile(path string, content []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,39 @@
+package keystore
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sss"
+)
+
+// ExportShares splits the key of address into n Shamir shares, any threshold
+// of which restore it with ImportShares.
+func (m *Manager) ExportShares(address common.Address, password string, n, threshold int) ([]sss.Share, error) {
+	key, err := m.decrypt(address, password)
+	if err != nil {
+		return nil, err
+	}
+	defer ZeroKey(key)
+
+	secret := crypto.FromECDSA(key)
+	defer clear(secret)
+	return sss.Split(secret, n, threshold)
+}
+
+// ImportShares restores a key split by ExportShares and stores it encrypted
+// with password.
+func (m *Manager) ImportShares(shares []sss.Share, password string) (Account, error) {
+	secret, err := sss.Combine(shares)
+	if err != nil {
+		return Account{}, err
+	}
+	defer clear(secret)
+
+	key, err := crypto.ToECDSA(secret)
+	if err != nil {
+		return Account{}, err
+	}
+	defer ZeroKey(key)
+
+	return m.ImportECDSA(key, password)
+}
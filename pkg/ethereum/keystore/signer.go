@@ -0,0 +1,39 @@
+package keystore
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+)
+
+var _ ethereum.Signer = (*Signer)(nil)
+
+// Signer signs with an account of a Manager. It fails with ErrLocked while the
+// account is locked.
+type Signer struct {
+	manager *Manager
+	address common.Address
+}
+
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	return s.manager.signHash(s.address, payload)
+}
+
+func (s *Signer) SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	return ethereum.SignTxWithHash(s, chainID, tx)
+}
+
+func (s *Signer) SignText(message []byte) ([]byte, error) {
+	return ethereum.SignTextWithHash(s, message)
+}
+
+func (s *Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return ethereum.SignTypedDataWithHash(s, typedData)
+}
@@ -0,0 +1,111 @@
+package kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidDER       = errors.New("invalid DER signature")
+	ErrInvalidPublicKey = errors.New("invalid secp256k1 public key")
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ParseDERSignature decodes an ASN.1 DER ECDSA-Sig-Value and checks that r and
+// s are in [1, n-1].
+func ParseDERSignature(der []byte) (r, s *big.Int, err error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, nil, errors.Wrap(ErrInvalidDER, err.Error())
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.Wrap(ErrInvalidDER, "trailing data")
+	}
+
+	if sig.R.Sign() <= 0 || sig.R.Cmp(ethereum.Secp256k1N) >= 0 || sig.S.Sign() <= 0 || sig.S.Cmp(ethereum.Secp256k1N) >= 0 {
+		return nil, nil, errors.Wrap(ErrInvalidDER, "r or s out of range")
+	}
+	return sig.R, sig.S, nil
+}
+
+// MarshalDERSignature encodes r and s as an ASN.1 DER ECDSA-Sig-Value.
+func MarshalDERSignature(r, s *big.Int) ([]byte, error) {
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+// ParsePublicKey accepts a secp256k1 public key as a DER SubjectPublicKeyInfo,
+// as returned by cloud KMS APIs, or as raw 65-byte uncompressed or 33-byte
+// compressed bytes, as exported by PKCS#11 HSMs.
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	switch {
+	case len(data) == 65 && data[0] == 0x04:
+		pub, err := crypto.UnmarshalPubkey(data)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPublicKey, err.Error())
+		}
+		return pub, nil
+	case len(data) == 33 && (data[0] == 0x02 || data[0] == 0x03):
+		pub, err := crypto.DecompressPubkey(data)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPublicKey, err.Error())
+		}
+		return pub, nil
+	}
+
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(data, &spki)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPublicKey, err.Error())
+	}
+	if len(rest) != 0 {
+		return nil, errors.Wrap(ErrInvalidPublicKey, "trailing data")
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.Wrapf(ErrInvalidPublicKey, "algorithm %s", spki.Algorithm.Algorithm)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidSecp256k1) {
+		return nil, errors.Wrap(ErrInvalidPublicKey, "curve is not secp256k1")
+	}
+	pub, err := crypto.UnmarshalPubkey(spki.PublicKey.RightAlign())
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPublicKey, err.Error())
+	}
+	return pub, nil
+}
+
+// MarshalPublicKey encodes pub as a DER SubjectPublicKeyInfo.
+func MarshalPublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	curve, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	point := crypto.FromECDSAPub(pub)
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: curve},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
@@ -0,0 +1,105 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+var ErrNoRecoveryID = errors.New("signature doesn't recover to the signer's public key")
+
+// Backend signs 32-byte digests with a secp256k1 key it never exposes, e.g. a
+// cloud KMS key or a PKCS#11 HSM object, and returns ASN.1 DER signatures.
+type Backend interface {
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Signer adapts a Backend to ethereum.Signer. DER signatures carry no recovery
+// id, so it is found by trial recovery against the known public key.
+type Signer struct {
+	backend   Backend
+	publicKey []byte
+	address   common.Address
+	timeout   time.Duration
+}
+
+var _ ethereum.Signer = (*Signer)(nil)
+
+func NewSigner(backend Backend, publicKey *ecdsa.PublicKey) *Signer {
+	return &Signer{
+		backend:   backend,
+		publicKey: crypto.FromECDSAPub(publicKey),
+		address:   crypto.PubkeyToAddress(*publicKey),
+		timeout:   DefaultTimeout,
+	}
+}
+
+// SetTimeout bounds each backend call.
+func (s *Signer) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+// Sign signs a digest with the backend and returns [R || S || V] with low S
+// and V in {0, 1}.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	der, err := s.backend.SignDigest(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "backend sign")
+	}
+	r, sv, err := ParseDERSignature(der)
+	if err != nil {
+		return nil, err
+	}
+	return RecoverableSignature(payload, r, sv, s.publicKey)
+}
+
+func (s *Signer) SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	return ethereum.SignTxWithHash(s, chainID, tx)
+}
+
+func (s *Signer) SignText(message []byte) ([]byte, error) {
+	return ethereum.SignTextWithHash(s, message)
+}
+
+func (s *Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return ethereum.SignTypedDataWithHash(s, typedData)
+}
+
+// RecoverableSignature turns (r, s) over hash into the 65-byte [R || S || V]
+// format. S is normalized to the lower half of the curve order as required by
+// EIP-2, and V is the recovery id that yields publicKey (uncompressed).
+func RecoverableSignature(hash []byte, r, s *big.Int, publicKey []byte) ([]byte, error) {
+	if s.Cmp(ethereum.Secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(ethereum.Secp256k1N, s)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[crypto.RecoveryIDOffset] = v
+		recovered, err := crypto.Ecrecover(hash, sig)
+		if err == nil && bytes.Equal(recovered, publicKey) {
+			return sig, nil
+		}
+	}
+	return nil, ErrNoRecoveryID
+}
@@ -0,0 +1,116 @@
+package kms
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := NewSoftwareBackend(key)
+
+	spki, err := backend.PublicKey()
+	require.NoError(t, err)
+	publicKey, err := ParsePublicKey(spki)
+	require.NoError(t, err)
+	signer := NewSigner(backend, publicKey)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	for _, highS := range []bool{false, true} {
+		backend.HighS = highS
+		for i := 0; i < 16; i++ {
+			hash := crypto.Keccak256(big.NewInt(int64(i)).Bytes())
+			sig, err := signer.Sign(hash)
+			require.NoError(t, err)
+
+			// crypto.Sign is deterministic and always low-s
+			expected, err := crypto.Sign(hash, key)
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+			require.True(t, crypto.ValidateSignatureValues(sig[64], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), true))
+		}
+	}
+
+	chainID := big.NewInt(1)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)})
+	sig, err := signer.Sign(types.LatestSignerForChainID(chainID).Hash(tx).Bytes())
+	require.NoError(t, err)
+	signed, err := (&ethereum.Transactor{}).AddSignatureToTransaction(chainID, tx, sig)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+}
+
+func TestSignerWrongPublicKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := NewSigner(NewSoftwareBackend(key), &other.PublicKey)
+	_, err = signer.Sign(crypto.Keccak256([]byte("hello")))
+	require.ErrorIs(t, err, ErrNoRecoveryID)
+}
+
+func TestSignerTimeout(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := NewSigner(slowBackend{NewSoftwareBackend(key)}, &key.PublicKey)
+	signer.SetTimeout(10 * time.Millisecond)
+	_, err = signer.Sign(crypto.Keccak256([]byte("hello")))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type slowBackend struct {
+	Backend
+}
+
+func (b slowBackend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	<-ctx.Done()
+	return b.Backend.SignDigest(ctx, digest)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, data := range [][]byte{crypto.FromECDSAPub(&key.PublicKey), crypto.CompressPubkey(&key.PublicKey)} {
+		pub, err := ParsePublicKey(data)
+		require.NoError(t, err)
+		require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+	}
+
+	// P-256 SubjectPublicKeyInfo
+	p256 := common.FromHex("3059301306072a8648ce3d020106082a8648ce3d030107034200046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")
+	_, err = ParsePublicKey(p256)
+	require.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestParseDERSignature(t *testing.T) {
+	der, err := MarshalDERSignature(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	r, s, err := ParseDERSignature(der)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), r.Int64())
+	require.Equal(t, int64(2), s.Int64())
+
+	_, _, err = ParseDERSignature(append(der, 0x00))
+	require.ErrorIs(t, err, ErrInvalidDER)
+
+	der, err = MarshalDERSignature(big.NewInt(1), ethereum.Secp256k1N)
+	require.NoError(t, err)
+	_, _, err = ParseDERSignature(der)
+	require.ErrorIs(t, err, ErrInvalidDER)
+}
@@ -0,0 +1,48 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+)
+
+// SoftwareBackend is an in-memory Backend standing in for a KMS or HSM in
+// tests. Like real devices it returns DER and doesn't normalize S.
+type SoftwareBackend struct {
+	key *ecdsa.PrivateKey
+	// HighS makes every signature use the high-s form, which many HSMs emit
+	// half of the time.
+	HighS bool
+}
+
+var _ Backend = (*SoftwareBackend)(nil)
+
+func NewSoftwareBackend(key *ecdsa.PrivateKey) *SoftwareBackend {
+	return &SoftwareBackend{key: key}
+}
+
+// PublicKey returns the public key as a DER SubjectPublicKeyInfo, like the
+// GetPublicKey call of cloud KMS APIs.
+func (b *SoftwareBackend) PublicKey() ([]byte, error) {
+	return MarshalPublicKey(&b.key.PublicKey)
+}
+
+func (b *SoftwareBackend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(digest, b.key)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if b.HighS {
+		s.Sub(ethereum.Secp256k1N, s)
+	}
+	return MarshalDERSignature(r, s)
+}
@@ -0,0 +1,316 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrManagerClosed   = errors.New("transactor manager is closed")
+	ErrNoRPCEndpoint   = errors.New("chain has no rpc endpoint")
+	ErrChainIDMismatch = errors.New("rpc endpoint serves a different chain")
+)
+
+type TransactorManagerConfig struct {
+	// Chains is the registry transactors are built from, DefaultChainRegistry if nil.
+	Chains *wallet_common.ChainRegistry
+	// Dial opens the client of a chain. Defaults to dialing the chain's RPC
+	// endpoints in order and checking the chain id they report.
+	Dial func(ctx context.Context, chain *wallet_common.ChainConfig) (*ethclient.Client, error)
+	// PendingTracker returns the pending tx tracker of a chain, NoopPendingTxTracker if nil.
+	PendingTracker func(chainID wallet_common.ChainID) IPendingTxTracker
+}
+
+// nonceState remembers the last nonce used per sender so that consecutive sends
+// don't depend on the node counting pending transactions.
+type nonceState struct {
+	mu    sync.Mutex
+	locks map[common.Address]*sync.Mutex
+	last  map[common.Address]int64
+}
+
+func newNonceState() *nonceState {
+	return &nonceState{
+		locks: make(map[common.Address]*sync.Mutex),
+		last:  make(map[common.Address]int64),
+	}
+}
+
+// lock serializes sends of a sender and returns its last used nonce, -1 if unknown.
+func (n *nonceState) lock(from common.Address) (unlock func(), lastUsedNonce int64) {
+	n.mu.Lock()
+	l, ok := n.locks[from]
+	if !ok {
+		l = &sync.Mutex{}
+		n.locks[from] = l
+	}
+	n.mu.Unlock()
+
+	l.Lock()
+
+	n.mu.Lock()
+	lastUsedNonce, ok = n.last[from]
+	n.mu.Unlock()
+	if !ok {
+		lastUsedNonce = -1
+	}
+	return l.Unlock, lastUsedNonce
+}
+
+func (n *nonceState) set(from common.Address, nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.last[from] = int64(nonce)
+}
+
+// reset forgets the sender's nonce so that the next send asks the node again.
+func (n *nonceState) reset(from common.Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.last, from)
+}
+
+type managedTransactor struct {
+	transactor *Transactor
+	client     *ethclient.Client
+	nonces     *nonceState
+}
+
+// pendingDial is a chain whose client is being dialed. Concurrent users of the
+// chain wait for done instead of dialing it again.
+type pendingDial struct {
+	done chan struct{}
+	mt   *managedTransactor
+	err  error
+}
+
+// TransactorManager owns one Transactor per chain. Transactors are built lazily
+// from the chain registry the first time a chain is used.
+type TransactorManager struct {
+	chains         *wallet_common.ChainRegistry
+	dial           func(ctx context.Context, chain *wallet_common.ChainConfig) (*ethclient.Client, error)
+	pendingTracker func(chainID wallet_common.ChainID) IPendingTxTracker
+
+	mu          sync.Mutex
+	transactors map[wallet_common.ChainID]*managedTransactor
+	dials       map[wallet_common.ChainID]*pendingDial
+	closed      bool
+	inflight    sync.WaitGroup
+}
+
+func NewTransactorManager(config TransactorManagerConfig) *TransactorManager {
+	m := &TransactorManager{
+		chains:         config.Chains,
+		dial:           config.Dial,
+		pendingTracker: config.PendingTracker,
+		transactors:    make(map[wallet_common.ChainID]*managedTransactor),
+		dials:          make(map[wallet_common.ChainID]*pendingDial),
+	}
+	if m.chains == nil {
+		m.chains = wallet_common.DefaultChainRegistry
+	}
+	if m.dial == nil {
+		m.dial = dialChain
+	}
+	return m
+}
+
+func dialChain(ctx context.Context, chain *wallet_common.ChainConfig) (*ethclient.Client, error) {
+	var lastErr error = errors.Wrapf(ErrNoRPCEndpoint, "chain %d", chain.ChainID)
+	for _, endpoint := range chain.RPC {
+		client, err := ethclient.DialContext(ctx, endpoint)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			client.Close()
+			lastErr = err
+			continue
+		}
+		if chainID.Uint64() != chain.ChainID.ToUint() {
+			client.Close()
+			lastErr = errors.Wrapf(ErrChainIDMismatch, "%s: expected %d, got %s", endpoint, chain.ChainID, chainID)
+			continue
+		}
+
+		return client, nil
+	}
+	return nil, lastErr
+}
+
+// get returns the transactor of chainID, dialing the chain on first use. The
+// dial runs outside m.mu so that a slow endpoint doesn't hold up other chains.
+func (m *TransactorManager) get(ctx context.Context, chainID wallet_common.ChainID) (*managedTransactor, error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, ErrManagerClosed
+	}
+	if mt, ok := m.transactors[chainID]; ok {
+		m.mu.Unlock()
+		return mt, nil
+	}
+	if dial, ok := m.dials[chainID]; ok {
+		m.mu.Unlock()
+		select {
+		case <-dial.done:
+			return dial.mt, dial.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	chain, ok := m.chains.Get(chainID)
+	if !ok {
+		m.mu.Unlock()
+		return nil, errors.Wrapf(wallet_common.ErrUnknownChain, "chain %d", chainID)
+	}
+	dial := &pendingDial{done: make(chan struct{})}
+	m.dials[chainID] = dial
+	m.mu.Unlock()
+
+	dial.mt, dial.err = m.build(ctx, chain)
+
+	m.mu.Lock()
+	delete(m.dials, chainID)
+	if dial.err == nil {
+		if m.closed {
+			// Close didn't see the client, so it's ours to close
+			dial.mt.client.Close()
+			dial.mt, dial.err = nil, ErrManagerClosed
+		} else {
+			m.transactors[chainID] = dial.mt
+		}
+	}
+	m.mu.Unlock()
+	close(dial.done)
+	return dial.mt, dial.err
+}
+
+func (m *TransactorManager) build(ctx context.Context, chain *wallet_common.ChainConfig) (*managedTransactor, error) {
+	client, err := m.dial(ctx, chain)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial chain %d", chain.ChainID)
+	}
+
+	var pendingTracker IPendingTxTracker
+	if m.pendingTracker != nil {
+		pendingTracker = m.pendingTracker(chain.ChainID)
+	}
+
+	transactor := NewTransactor(client, new(big.Int).SetUint64(chain.ChainID.ToUint()), pendingTracker)
+	transactor.SetChainRegistry(m.chains)
+
+	return &managedTransactor{
+		transactor: transactor,
+		client:     client,
+		nonces:     newNonceState(),
+	}, nil
+}
+
+// Transactor returns the transactor of chainID, building it on first use.
+func (m *TransactorManager) Transactor(ctx context.Context, chainID wallet_common.ChainID) (*Transactor, error) {
+	mt, err := m.get(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return mt.transactor, nil
+}
+
+// SendTransaction routes sendArgs to the transactor of chainID. Sends from the
+// same address on the same chain are serialized and get consecutive nonces.
+func (m *TransactorManager) SendTransaction(
+	ctx context.Context,
+	chainID wallet_common.ChainID,
+	sendArgs _types.SendTxArgs,
+	signer Signer,
+) (hash _types.Hash, nonce uint64, err error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return hash, 0, ErrManagerClosed
+	}
+	m.inflight.Add(1)
+	m.mu.Unlock()
+	defer m.inflight.Done()
+
+	mt, err := m.get(ctx, chainID)
+	if err != nil {
+		return hash, 0, err
+	}
+
+	unlock, lastUsedNonce := mt.nonces.lock(sendArgs.From)
+	defer unlock()
+
+	hash, nonce, err = mt.transactor.SendTransactionWithChainID(
+		ctx,
+		new(big.Int).SetUint64(chainID.ToUint()),
+		sendArgs,
+		signer,
+		lastUsedNonce,
+	)
+	if err != nil {
+		mt.nonces.reset(sendArgs.From)
+		return hash, nonce, err
+	}
+
+	if sendArgs.Nonce == nil || int64(nonce) > lastUsedNonce {
+		mt.nonces.set(sendArgs.From, nonce)
+	}
+	return hash, nonce, nil
+}
+
+// ResetNonce drops the cached nonce of from on chainID, e.g. after a transaction
+// was replaced or dropped outside of the manager.
+func (m *TransactorManager) ResetNonce(chainID wallet_common.ChainID, from common.Address) {
+	m.mu.Lock()
+	mt, ok := m.transactors[chainID]
+	m.mu.Unlock()
+	if ok {
+		mt.nonces.reset(from)
+	}
+}
+
+// ChainIDs returns the chains that currently have a transactor.
+func (m *TransactorManager) ChainIDs() []wallet_common.ChainID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]wallet_common.ChainID, 0, len(m.transactors))
+	for id := range m.transactors {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Close rejects new sends, waits for the in-flight ones and closes all clients.
+func (m *TransactorManager) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	m.mu.Unlock()
+
+	m.inflight.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, mt := range m.transactors {
+		mt.client.Close()
+		zap.S().Debugw("closed transactor", "chainID", id)
+	}
+	m.transactors = make(map[wallet_common.ChainID]*managedTransactor)
+}
@@ -0,0 +1,120 @@
+package ethereum
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	wallet_common "github.com/openweb3-io/anychain/pkg/ethereum/common"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactorManager(t *testing.T) {
+	apis := map[wallet_common.ChainID]*fakeEthAPI{
+		wallet_common.ChainID(wallet_common.EthereumMainnet): newFakeEthAPI(wallet_common.EthereumMainnet),
+		wallet_common.ChainID(wallet_common.ArbitrumMainnet): newFakeEthAPI(wallet_common.ArbitrumMainnet),
+	}
+	dials := 0
+	manager := NewTransactorManager(TransactorManagerConfig{
+		Dial: func(ctx context.Context, chain *wallet_common.ChainConfig) (*ethclient.Client, error) {
+			dials++
+			return newFakeClient(apis[chain.ChainID]), nil
+		},
+	})
+
+	key, _ := gethcrypto.GenerateKey()
+	signer := NewPrivateKeySigner(key)
+	from := gethcrypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	args := _types.SendTxArgs{From: from, To: &to}
+
+	ctx := context.Background()
+	for chainID, api := range apis {
+		for i := uint64(0); i < 3; i++ {
+			hash, nonce, err := manager.SendTransaction(ctx, chainID, args, signer)
+			require.NoError(t, err)
+			require.Equal(t, i, nonce)
+
+			sent := api.sentTxs()
+			require.Len(t, sent, int(i+1))
+			require.Equal(t, common.Hash(hash), sent[i].Hash())
+			require.Equal(t, chainID.ToUint(), sent[i].ChainId().Uint64())
+
+			sender, err := types.Sender(types.LatestSignerForChainID(sent[i].ChainId()), sent[i])
+			require.NoError(t, err)
+			require.Equal(t, from, sender)
+		}
+	}
+	require.Equal(t, 2, dials)
+	require.Len(t, manager.ChainIDs(), 2)
+
+	_, _, err := manager.SendTransaction(ctx, 12345, args, signer)
+	require.ErrorIs(t, err, wallet_common.ErrUnknownChain)
+
+	manager.Close()
+	_, _, err = manager.SendTransaction(ctx, wallet_common.ChainID(wallet_common.EthereumMainnet), args, signer)
+	require.ErrorIs(t, err, ErrManagerClosed)
+}
+
+func TestTransactorManagerDial(t *testing.T) {
+	mainnet := wallet_common.ChainID(wallet_common.EthereumMainnet)
+	arbitrum := wallet_common.ChainID(wallet_common.ArbitrumMainnet)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mainnetDials atomic.Int32
+	manager := NewTransactorManager(TransactorManagerConfig{
+		Dial: func(ctx context.Context, chain *wallet_common.ChainConfig) (*ethclient.Client, error) {
+			if chain.ChainID == mainnet {
+				if mainnetDials.Add(1) == 1 {
+					close(started)
+				}
+				<-release
+			}
+			return newFakeClient(newFakeEthAPI(chain.ChainID.ToUint())), nil
+		},
+	})
+	defer manager.Close()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	transactors := make([]*Transactor, 3)
+	errs := make([]error, len(transactors))
+	for i := range transactors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transactors[i], errs[i] = manager.Transactor(ctx, mainnet)
+		}()
+	}
+	<-started
+
+	// a slow endpoint doesn't hold up other chains
+	done := make(chan error)
+	go func() {
+		_, err := manager.Transactor(ctx, arbitrum)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("dialing a chain blocked another one")
+	}
+
+	close(release)
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), mainnetDials.Load())
+	require.Same(t, transactors[0], transactors[1])
+	require.Same(t, transactors[0], transactors[2])
+}
@@ -0,0 +1,126 @@
+package mpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+var (
+	one = big.NewInt(1)
+
+	curve  = crypto.S256()
+	curveN = curve.Params().N
+)
+
+var ErrInvalidPoint = errors.New("invalid curve point")
+
+// point is an affine secp256k1 point. The point at infinity is never valid in
+// these protocols and can't be encoded.
+type point struct {
+	X, Y *big.Int
+}
+
+func baseMul(k *big.Int) point {
+	x, y := curve.ScalarBaseMult(scalarBytes(k))
+	return point{x, y}
+}
+
+func (p point) mul(k *big.Int) point {
+	x, y := curve.ScalarMult(p.X, p.Y, scalarBytes(k))
+	return point{x, y}
+}
+
+func (p point) add(q point) point {
+	x, y := curve.Add(p.X, p.Y, q.X, q.Y)
+	return point{x, y}
+}
+
+func (p point) equal(q point) bool {
+	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+}
+
+func (p point) isInfinity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+func (p point) bytes() []byte {
+	return crypto.CompressPubkey(publicKeyECDSA(p))
+}
+
+func decodePoint(b []byte) (point, error) {
+	pub, err := crypto.DecompressPubkey(b)
+	if err != nil {
+		return point{}, errors.Wrap(ErrInvalidPoint, err.Error())
+	}
+	return point{pub.X, pub.Y}, nil
+}
+
+func scalarBytes(k *big.Int) []byte {
+	return new(big.Int).Mod(k, curveN).FillBytes(make([]byte, 32))
+}
+
+// randomScalar returns a uniform scalar in [1, n-1].
+func randomScalar(random io.Reader) (*big.Int, error) {
+	for {
+		k, err := rand.Int(random, curveN)
+		if err != nil {
+			return nil, err
+		}
+		if k.Sign() > 0 {
+			return k, nil
+		}
+	}
+}
+
+func modN(k *big.Int) *big.Int {
+	return k.Mod(k, curveN)
+}
+
+// evalPolynomial evaluates sum(coefficients[k] * x^k) mod n.
+func evalPolynomial(coefficients []*big.Int, x PartyID) *big.Int {
+	result := new(big.Int)
+	xi := big.NewInt(int64(x))
+	for k := len(coefficients) - 1; k >= 0; k-- {
+		result.Mul(result, xi)
+		result.Add(result, coefficients[k])
+		modN(result)
+	}
+	return result
+}
+
+// evalCommitments evaluates sum(commitments[k] * x^k), i.e. the polynomial
+// in the exponent.
+func evalCommitments(commitments []point, x PartyID) point {
+	result := commitments[0]
+	xk := big.NewInt(1)
+	xi := big.NewInt(int64(x))
+	for k := 1; k < len(commitments); k++ {
+		modN(xk.Mul(xk, xi))
+		result = result.add(commitments[k].mul(xk))
+	}
+	return result
+}
+
+// lagrangeCoefficient returns the coefficient of party i to interpolate the
+// secret at 0 from the shares of parties.
+func lagrangeCoefficient(i PartyID, parties []PartyID) *big.Int {
+	num := big.NewInt(1)
+	den := big.NewInt(1)
+	for _, j := range parties {
+		if j == i {
+			continue
+		}
+		modN(num.Mul(num, big.NewInt(int64(j))))
+		modN(den.Mul(den, big.NewInt(int64(j)-int64(i))))
+	}
+	return modN(num.Mul(num, new(big.Int).ModInverse(den, curveN)))
+}
+
+func publicKeyECDSA(p point) *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{Curve: curve, X: p.X, Y: p.Y}
+}
@@ -0,0 +1,342 @@
+package mpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinPaillierBits keeps MtA products and masks below N, so shares never
+	// wrap, even for the largest values the range proofs accept.
+	MinPaillierBits = 2048
+
+	roundKeygenShares  = "keygen/shares"
+	roundKeygenConfirm = "keygen/confirm"
+
+	abortTimeout = 5 * time.Second
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid ceremony config")
+	ErrInvalidShare  = errors.New("invalid key share")
+)
+
+type KeygenConfig struct {
+	// Session must be the same for all parties of the ceremony.
+	Session string
+	Self    PartyID
+	Parties []PartyID
+	// Threshold is the number of parties needed to sign.
+	Threshold    int
+	PaillierBits int
+}
+
+func (c *KeygenConfig) validate() error {
+	if c.Session == "" {
+		return errors.Wrap(ErrInvalidConfig, "session is required")
+	}
+	if err := validateParties(c.Parties, c.Self); err != nil {
+		return err
+	}
+	if c.Threshold < 2 || c.Threshold > len(c.Parties) {
+		return errors.Wrapf(ErrInvalidConfig, "threshold must be between 2 and %d", len(c.Parties))
+	}
+	if c.PaillierBits < MinPaillierBits {
+		return errors.Wrapf(ErrInvalidConfig, "paillier modulus must be at least %d bits", MinPaillierBits)
+	}
+	return nil
+}
+
+func validateParties(parties []PartyID, self PartyID) error {
+	seen := make(map[PartyID]bool, len(parties))
+	for _, id := range parties {
+		if id <= 0 {
+			return errors.Wrapf(ErrInvalidConfig, "party id %d must be positive", id)
+		}
+		if seen[id] {
+			return errors.Wrapf(ErrInvalidConfig, "duplicate party %d", id)
+		}
+		seen[id] = true
+	}
+	if !seen[self] {
+		return errors.Wrapf(ErrInvalidConfig, "party %d is not part of the ceremony", self)
+	}
+	return nil
+}
+
+// KeyShare is the output of Keygen for one party. Share and Paillier are
+// secret; the rest is common to all parties.
+type KeyShare struct {
+	ID        PartyID   `json:"id"`
+	Threshold int       `json:"threshold"`
+	Parties   []PartyID `json:"parties"`
+
+	Share *big.Int `json:"share"`
+	// PublicKey is the compressed group public key.
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	// PublicShares are the compressed Share*G of every party.
+	PublicShares map[PartyID]hexutil.Bytes `json:"publicShares"`
+
+	Paillier     *PaillierPrivateKey            `json:"paillier"`
+	PaillierKeys map[PartyID]*PaillierPublicKey `json:"paillierKeys"`
+	// Pedersen are the ring-Pedersen parameters of every party, which the
+	// range proofs for that party are computed with.
+	Pedersen map[PartyID]*PedersenParams `json:"pedersen"`
+}
+
+// Address returns the Ethereum address of the group public key.
+func (s *KeyShare) Address() (common.Address, error) {
+	pub, err := crypto.DecompressPubkey(s.PublicKey)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+type keygenShares struct {
+	Commitments []hexutil.Bytes `json:"commitments"`
+	Share       *big.Int        `json:"share"`
+	Paillier    *big.Int        `json:"paillier"`
+	Pedersen    *PedersenParams `json:"pedersen"`
+	// ModProof shows Paillier is a Paillier-Blum modulus, PedersenProof that
+	// Pedersen hides commitments and SecretProof that the dealer knows the
+	// secret behind Commitments[0].
+	ModProof      *modProof     `json:"modProof"`
+	PedersenProof *prmProof     `json:"pedersenProof"`
+	SecretProof   *schnorrProof `json:"secretProof"`
+}
+
+type keygenConfirm struct {
+	PublicKey    hexutil.Bytes `json:"publicKey"`
+	PublicShares common.Hash   `json:"publicShares"`
+	// FactorProof shows the recipient that the sender's Paillier modulus has
+	// no small factors; ShareProof that the sender knows its key share.
+	FactorProof *facProof     `json:"factorProof"`
+	ShareProof  *schnorrProof `json:"shareProof"`
+}
+
+// Keygen runs the distributed key generation with the other parties of cfg.
+// Every party deals a Feldman-verifiable sharing of a random secret; the group
+// key is the sum of the secrets, which no party ever learns. Parties prove
+// knowledge of their secrets and shares, and that their Paillier keys and
+// ring-Pedersen parameters are well formed, as signing relies on both.
+func Keygen(ctx context.Context, cfg KeygenConfig, transport Transport) (share *KeyShare, err error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	parties := sortedParties(cfg.Parties)
+	others := without(parties, cfg.Self)
+	defer func() {
+		if err != nil {
+			abort(transport, cfg.Session, parties, cfg.Self, err)
+		}
+	}()
+
+	paillier, err := GeneratePaillierKey(rand.Reader, cfg.PaillierBits)
+	if err != nil {
+		return nil, err
+	}
+	pedersen, lambda, err := newPedersenParams(paillier)
+	if err != nil {
+		return nil, err
+	}
+	defer lambda.SetInt64(0)
+
+	coefficients := make([]*big.Int, cfg.Threshold)
+	commitments := make([]point, cfg.Threshold)
+	encoded := make([]hexutil.Bytes, cfg.Threshold)
+	for k := range coefficients {
+		if coefficients[k], err = randomScalar(rand.Reader); err != nil {
+			return nil, err
+		}
+		commitments[k] = baseMul(coefficients[k])
+		encoded[k] = commitments[k].bytes()
+	}
+	defer func() {
+		for _, c := range coefficients {
+			c.SetInt64(0)
+		}
+	}()
+
+	modulusProof, err := proveMod(newTranscript("keygen/mod", cfg.Session, cfg.Self, 0), paillier)
+	if err != nil {
+		return nil, err
+	}
+	pedersenProof, err := provePrm(newTranscript("keygen/prm", cfg.Session, cfg.Self, 0), pedersen, lambda, paillier.Phi)
+	if err != nil {
+		return nil, err
+	}
+	secretProof, err := proveSchnorr(newTranscript("keygen/secret", cfg.Session, cfg.Self, 0), coefficients[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range others {
+		msg := keygenShares{
+			Commitments:   encoded,
+			Share:         evalPolynomial(coefficients, id),
+			Paillier:      paillier.N,
+			Pedersen:      pedersen,
+			ModProof:      modulusProof,
+			PedersenProof: pedersenProof,
+			SecretProof:   secretProof,
+		}
+		if err := send(ctx, transport, cfg.Session, roundKeygenShares, id, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	in := &inbox{session: cfg.Session, receive: transport.Receive}
+	payloads, err := in.collect(ctx, roundKeygenShares, others)
+	if err != nil {
+		return nil, err
+	}
+
+	share = &KeyShare{
+		ID:           cfg.Self,
+		Threshold:    cfg.Threshold,
+		Parties:      parties,
+		Share:        evalPolynomial(coefficients, cfg.Self),
+		PublicShares: make(map[PartyID]hexutil.Bytes, len(parties)),
+		Paillier:     paillier,
+		PaillierKeys: map[PartyID]*PaillierPublicKey{cfg.Self: &paillier.PaillierPublicKey},
+		Pedersen:     map[PartyID]*PedersenParams{cfg.Self: pedersen},
+	}
+	allCommitments := map[PartyID][]point{cfg.Self: commitments}
+	for _, id := range others {
+		var msg keygenShares
+		if err := json.Unmarshal(payloads[id], &msg); err != nil {
+			return nil, errors.Wrapf(ErrInvalidMessage, "party %d: %s", id, err)
+		}
+		if len(msg.Commitments) != cfg.Threshold || msg.Share == nil || msg.Paillier == nil || msg.Pedersen == nil {
+			return nil, errors.Wrapf(ErrInvalidMessage, "party %d: malformed shares", id)
+		}
+		if msg.Paillier.BitLen() < MinPaillierBits {
+			return nil, errors.Wrapf(ErrInvalidMessage, "party %d: paillier modulus too small", id)
+		}
+		if !msg.ModProof.verify(newTranscript("keygen/mod", cfg.Session, id, 0), msg.Paillier) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: paillier modulus", id)
+		}
+		if msg.Pedersen.N == nil || msg.Pedersen.N.Cmp(msg.Paillier) != 0 ||
+			!msg.PedersenProof.verify(newTranscript("keygen/prm", cfg.Session, id, 0), msg.Pedersen) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: ring-pedersen parameters", id)
+		}
+
+		theirs := make([]point, cfg.Threshold)
+		for k, c := range msg.Commitments {
+			if theirs[k], err = decodePoint(c); err != nil {
+				return nil, errors.Wrapf(err, "party %d", id)
+			}
+		}
+		if !msg.SecretProof.verify(newTranscript("keygen/secret", cfg.Session, id, 0), theirs[0]) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: knowledge of secret", id)
+		}
+		// Feldman check: the share must lie on the committed polynomial
+		if msg.Share.Sign() <= 0 || msg.Share.Cmp(curveN) >= 0 || !baseMul(msg.Share).equal(evalCommitments(theirs, cfg.Self)) {
+			return nil, errors.Wrapf(ErrInvalidShare, "party %d dealt an inconsistent share", id)
+		}
+
+		modN(share.Share.Add(share.Share, msg.Share))
+		allCommitments[id] = theirs
+		share.PaillierKeys[id] = &PaillierPublicKey{N: msg.Paillier}
+		share.Pedersen[id] = msg.Pedersen
+	}
+
+	publicKey := allCommitments[parties[0]][0]
+	for _, id := range parties[1:] {
+		publicKey = publicKey.add(allCommitments[id][0])
+	}
+	share.PublicKey = publicKey.bytes()
+	for _, id := range parties {
+		public := evalCommitments(allCommitments[parties[0]], id)
+		for _, dealer := range parties[1:] {
+			public = public.add(evalCommitments(allCommitments[dealer], id))
+		}
+		share.PublicShares[id] = public.bytes()
+	}
+	if !bytes.Equal(baseMul(share.Share).bytes(), share.PublicShares[cfg.Self]) {
+		return nil, errors.Wrap(ErrInvalidShare, "own share doesn't match the commitments")
+	}
+
+	// make sure everybody saw the same commitments and keys
+	shareProof, err := proveSchnorr(newTranscript("keygen/share", cfg.Session, cfg.Self, 0), share.Share)
+	if err != nil {
+		return nil, err
+	}
+	confirm := keygenConfirm{PublicKey: share.PublicKey, PublicShares: share.publicSharesHash(), ShareProof: shareProof}
+	for _, id := range others {
+		msg := confirm
+		if msg.FactorProof, err = proveFac(newTranscript("keygen/fac", cfg.Session, cfg.Self, id), paillier, share.Pedersen[id]); err != nil {
+			return nil, err
+		}
+		if err := send(ctx, transport, cfg.Session, roundKeygenConfirm, id, msg); err != nil {
+			return nil, err
+		}
+	}
+	payloads, err = in.collect(ctx, roundKeygenConfirm, others)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range others {
+		var msg keygenConfirm
+		if err := json.Unmarshal(payloads[id], &msg); err != nil {
+			return nil, errors.Wrapf(ErrInvalidMessage, "party %d: %s", id, err)
+		}
+		if !bytes.Equal(msg.PublicKey, confirm.PublicKey) || msg.PublicShares != confirm.PublicShares {
+			return nil, errors.Wrapf(ErrInvalidShare, "party %d derived another public key", id)
+		}
+		if !msg.FactorProof.verify(newTranscript("keygen/fac", cfg.Session, id, cfg.Self), share.PaillierKeys[id].N, pedersen) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: paillier factors", id)
+		}
+		public, err := decodePoint(share.PublicShares[id])
+		if err != nil {
+			return nil, err
+		}
+		if !msg.ShareProof.verify(newTranscript("keygen/share", cfg.Session, id, 0), public) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: knowledge of share", id)
+		}
+	}
+	return share, nil
+}
+
+// publicSharesHash commits to the public shares, Paillier keys and
+// ring-Pedersen parameters of all parties.
+func (s *KeyShare) publicSharesHash() common.Hash {
+	var data [][]byte
+	for _, id := range sortedParties(s.Parties) {
+		data = append(data,
+			binary.BigEndian.AppendUint64(nil, uint64(id)),
+			s.PublicShares[id],
+			s.PaillierKeys[id].N.FillBytes(make([]byte, s.PaillierKeys[id].N.BitLen()/8+1)),
+			s.Pedersen[id].S.FillBytes(make([]byte, s.Pedersen[id].N.BitLen()/8+1)),
+			s.Pedersen[id].T.FillBytes(make([]byte, s.Pedersen[id].N.BitLen()/8+1)),
+		)
+	}
+	return crypto.Keccak256Hash(data...)
+}
+
+func sortedParties(parties []PartyID) []PartyID {
+	sorted := append([]PartyID(nil), parties...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func without(parties []PartyID, self PartyID) []PartyID {
+	others := make([]PartyID, 0, len(parties))
+	for _, id := range parties {
+		if id != self {
+			others = append(others, id)
+		}
+	}
+	return others
+}
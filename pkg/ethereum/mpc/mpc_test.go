@@ -0,0 +1,314 @@
+package mpc
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaillier(t *testing.T) {
+	key, err := GeneratePaillierKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	a, b := big.NewInt(1234), big.NewInt(5678)
+	encA, err := key.Encrypt(rand.Reader, a)
+	require.NoError(t, err)
+	encB, err := key.Encrypt(rand.Reader, b)
+	require.NoError(t, err)
+
+	sum, err := key.Decrypt(key.Add(encA, encB))
+	require.NoError(t, err)
+	require.Equal(t, int64(1234+5678), sum.Int64())
+
+	product, err := key.Decrypt(key.Mul(encA, big.NewInt(3)))
+	require.NoError(t, err)
+	require.Equal(t, int64(1234*3), product.Int64())
+
+	_, err = key.Decrypt(big.NewInt(0))
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestProofs(t *testing.T) {
+	prover, err := GeneratePaillierKey(rand.Reader, MinPaillierBits)
+	require.NoError(t, err)
+	verifierKey, err := GeneratePaillierKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	verifier, lambda, err := newPedersenParams(verifierKey)
+	require.NoError(t, err)
+	tr := func(session string) *transcript { return newTranscript("test", session, 1, 2) }
+
+	t.Run("mod", func(t *testing.T) {
+		proof, err := proveMod(tr("a"), prover)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), prover.N))
+		require.False(t, proof.verify(tr("b"), prover.N))
+		require.False(t, proof.verify(tr("a"), verifierKey.N))
+		proof.A[0] ^= 1
+		require.False(t, proof.verify(tr("a"), prover.N))
+	})
+
+	t.Run("prm", func(t *testing.T) {
+		proof, err := provePrm(tr("a"), verifier, lambda, verifierKey.Phi)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), verifier))
+		require.False(t, proof.verify(tr("b"), verifier))
+		// s isn't a power of t known to the prover
+		other := *verifier
+		other.S = new(big.Int).Mod(new(big.Int).Mul(verifier.S, verifier.S), verifier.N)
+		require.False(t, proof.verify(tr("a"), &other))
+	})
+
+	t.Run("fac", func(t *testing.T) {
+		proof, err := proveFac(tr("a"), prover, verifier)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), prover.N, verifier))
+		require.False(t, proof.verify(tr("b"), prover.N, verifier))
+		proof.Z1.Add(proof.Z1, one)
+		require.False(t, proof.verify(tr("a"), prover.N, verifier))
+	})
+
+	t.Run("enc", func(t *testing.T) {
+		x, err := randomScalar(rand.Reader)
+		require.NoError(t, err)
+		c, rho, err := prover.encryptWithNonce(rand.Reader, x)
+		require.NoError(t, err)
+		g := baseMul(big.NewInt(7))
+		X := g.mul(x)
+
+		proof, err := proveEnc(tr("a"), &prover.PaillierPublicKey, verifier, c, x, rho, nil)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, c, nil, nil))
+		require.False(t, proof.verify(tr("b"), &prover.PaillierPublicKey, verifier, c, nil, nil))
+
+		proof, err = proveEnc(tr("a"), &prover.PaillierPublicKey, verifier, c, x, rho, &g)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, c, &g, &X))
+		wrong := X.add(g)
+		require.False(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, c, &g, &wrong))
+
+		// a plaintext far out of range
+		large := pow2(rangeBits + slackBits + 8)
+		c, rho, err = prover.encryptWithNonce(rand.Reader, large)
+		require.NoError(t, err)
+		proof, err = proveEnc(tr("a"), &prover.PaillierPublicKey, verifier, c, large, rho, nil)
+		require.NoError(t, err)
+		require.False(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, c, nil, nil))
+	})
+
+	t.Run("aff", func(t *testing.T) {
+		a, err := randomScalar(rand.Reader)
+		require.NoError(t, err)
+		encA, err := prover.Encrypt(rand.Reader, a)
+		require.NoError(t, err)
+		b, err := randomScalar(rand.Reader)
+		require.NoError(t, err)
+		B := baseMul(b)
+
+		d, share, proof, err := mta(tr("a"), &prover.PaillierPublicKey, verifier, encA, b, &B)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, encA, d, &B))
+		require.False(t, proof.verify(tr("b"), &prover.PaillierPublicKey, verifier, encA, d, &B))
+		wrong := B.add(baseMul(one))
+		require.False(t, proof.verify(tr("a"), &prover.PaillierPublicKey, verifier, encA, d, &wrong))
+
+		alpha, err := prover.Decrypt(d)
+		require.NoError(t, err)
+		require.Zero(t, modN(new(big.Int).Add(alpha, share)).Cmp(modN(new(big.Int).Mul(a, b))))
+	})
+
+	t.Run("schnorr", func(t *testing.T) {
+		x, err := randomScalar(rand.Reader)
+		require.NoError(t, err)
+		proof, err := proveSchnorr(tr("a"), x)
+		require.NoError(t, err)
+		require.True(t, proof.verify(tr("a"), baseMul(x)))
+		require.False(t, proof.verify(tr("b"), baseMul(x)))
+		require.False(t, proof.verify(tr("a"), baseMul(one)))
+	})
+}
+
+func TestLagrangeInterpolation(t *testing.T) {
+	secret := big.NewInt(42)
+	coefficients := []*big.Int{secret, big.NewInt(7), big.NewInt(3)}
+
+	for _, signers := range [][]PartyID{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}} {
+		sum := new(big.Int)
+		for _, id := range signers {
+			sum.Add(sum, new(big.Int).Mul(lagrangeCoefficient(id, signers), evalPolynomial(coefficients, id)))
+		}
+		require.Equal(t, secret, modN(sum))
+	}
+}
+
+// runKeygen runs a threshold-of-parties key generation over a memory network.
+func runKeygen(t *testing.T, threshold int, parties []PartyID) (*MemoryNetwork, map[PartyID]*KeyShare) {
+	network := NewMemoryNetwork(parties...)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var mu sync.Mutex
+	shares := make(map[PartyID]*KeyShare, len(parties))
+	var wg sync.WaitGroup
+	errs := make(chan error, len(parties))
+	for _, id := range parties {
+		wg.Add(1)
+		go func(id PartyID) {
+			defer wg.Done()
+			share, err := Keygen(ctx, KeygenConfig{
+				Session:      "keygen",
+				Self:         id,
+				Parties:      parties,
+				Threshold:    threshold,
+				PaillierBits: MinPaillierBits,
+			}, network.Transport(id))
+			if err != nil {
+				errs <- errors.Wrapf(err, "party %d", id)
+				return
+			}
+			mu.Lock()
+			shares[id] = share
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	return network, shares
+}
+
+func TestThresholdSigner(t *testing.T) {
+	parties := []PartyID{1, 2, 3}
+	network, shares := runKeygen(t, 2, parties)
+
+	address, err := shares[1].Address()
+	require.NoError(t, err)
+	for _, id := range parties {
+		other, err := shares[id].Address()
+		require.NoError(t, err)
+		require.Equal(t, address, other)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var refused sync.Map
+	partyOf := make(map[PartyID]*Party, len(parties))
+	for _, id := range parties {
+		party, err := NewParty(shares[id], network.Transport(id), func(from PartyID, req *SignRequest) error {
+			if _, ok := refused.Load(string(req.Hash)); ok {
+				return fmt.Errorf("hash %x is not allowed", req.Hash)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		partyOf[id] = party
+		go party.Run(ctx)
+	}
+
+	chainID := big.NewInt(1)
+	for _, signers := range [][]PartyID{{1, 2}, {2, 3}, {3, 1}, {1, 2, 3}} {
+		t.Run(fmt.Sprint(signers), func(t *testing.T) {
+			signer, err := NewSigner(partyOf[signers[0]], signers)
+			require.NoError(t, err)
+			require.Equal(t, address, signer.Address())
+
+			hash := crypto.Keccak256([]byte(fmt.Sprint(signers)))
+			sig, err := signer.Sign(hash)
+			require.NoError(t, err)
+			require.Len(t, sig, crypto.SignatureLength)
+			pub, err := crypto.SigToPub(hash, sig)
+			require.NoError(t, err)
+			require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+
+			tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000, GasFeeCap: big.NewInt(1e9), GasTipCap: big.NewInt(1e9)})
+			signed, err := signer.SignTx(chainID, tx)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			require.Equal(t, address, sender)
+		})
+	}
+
+	_, err = NewSigner(partyOf[1], []PartyID{1})
+	require.ErrorIs(t, err, ErrNotEnoughSigners)
+
+	// a co-signer refusing the request aborts the ceremony
+	signer, err := NewSigner(partyOf[1], []PartyID{1, 2})
+	require.NoError(t, err)
+	hash := crypto.Keccak256([]byte("refused"))
+	refused.Store(string(hash), true)
+	_, err = signer.Sign(hash)
+	require.ErrorIs(t, err, ErrAborted)
+
+	// an offline co-signer makes the ceremony time out
+	offline, err := NewParty(shares[3], NewMemoryNetwork(3).Transport(3), RejectAll)
+	require.NoError(t, err)
+	signer, err = NewSigner(offline, []PartyID{3, 1})
+	require.NoError(t, err)
+	signer.SetTimeout(100 * time.Millisecond)
+	_, err = signer.Sign(crypto.Keccak256([]byte("offline")))
+	require.Error(t, err)
+}
+
+func TestPartyEarlyMessages(t *testing.T) {
+	share := &KeyShare{ID: 1, PublicShares: map[PartyID]hexutil.Bytes{1: nil, 2: nil}}
+	_, err := NewParty(share, NewMemoryNetwork(1).Transport(1), nil)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	party, err := NewParty(share, NewMemoryNetwork(1).Transport(1), RejectAll)
+	require.NoError(t, err)
+
+	// messages from unknown parties and late aborts aren't kept
+	party.deliver(&Message{Session: "a", Round: roundSignCommit, From: 3})
+	party.deliver(&Message{Session: "a", Round: roundAbort, From: 2})
+	require.Empty(t, party.early)
+
+	// a peer can't buffer more than maxEarlyPerParty messages
+	for i := 0; i < 2*maxEarlyPerParty; i++ {
+		party.deliver(&Message{Session: fmt.Sprint(i), Round: roundSignCommit, From: 2})
+	}
+	require.Len(t, party.early, maxEarlyPerParty)
+	require.Equal(t, maxEarlyPerParty, party.earlyCount[2])
+
+	// starting a ceremony hands its early messages over
+	queue, ok := party.register("0")
+	require.True(t, ok)
+	require.Len(t, queue, 1)
+	require.Equal(t, maxEarlyPerParty-1, party.earlyCount[2])
+
+	// expired messages are dropped
+	for _, early := range party.early {
+		early.received = time.Now().Add(-2 * earlyTTL)
+	}
+	party.deliver(&Message{Session: "b", Round: roundSignCommit, From: 2})
+	require.Len(t, party.early, 1)
+	require.Equal(t, 1, party.earlyCount[2])
+
+	party.unregister("b")
+	require.Empty(t, party.early)
+	require.Empty(t, party.earlyCount)
+}
+
+func TestKeygenConfig(t *testing.T) {
+	transport := NewMemoryNetwork(1, 2).Transport(1)
+	for _, cfg := range []KeygenConfig{
+		{Session: "s", Self: 1, Parties: []PartyID{1, 2}, Threshold: 3, PaillierBits: MinPaillierBits},
+		{Session: "s", Self: 1, Parties: []PartyID{1, 1}, Threshold: 2, PaillierBits: MinPaillierBits},
+		{Session: "s", Self: 3, Parties: []PartyID{1, 2}, Threshold: 2, PaillierBits: MinPaillierBits},
+		{Session: "s", Self: 1, Parties: []PartyID{1, 2}, Threshold: 2, PaillierBits: 1024},
+	} {
+		_, err := Keygen(context.Background(), cfg, transport)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	}
+}
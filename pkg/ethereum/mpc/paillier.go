@@ -0,0 +1,141 @@
+package mpc
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// PaillierPublicKey is a Paillier public key with generator N+1. N doubles as
+// the modulus of the owner's ring-Pedersen parameters.
+type PaillierPublicKey struct {
+	N *big.Int `json:"n"`
+}
+
+// PaillierPrivateKey keeps the factors of N, which are Blum primes so that the
+// owner can prove N is a Paillier-Blum modulus.
+type PaillierPrivateKey struct {
+	PaillierPublicKey
+	P   *big.Int `json:"p"`
+	Q   *big.Int `json:"q"`
+	Phi *big.Int `json:"phi"`
+}
+
+var ErrInvalidCiphertext = errors.New("invalid paillier ciphertext")
+
+// GeneratePaillierKey generates a key whose modulus N has bits bits.
+func GeneratePaillierKey(random io.Reader, bits int) (*PaillierPrivateKey, error) {
+	for {
+		p, err := blumPrime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := blumPrime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+		phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		// always true for primes of the same size, but cheap to check
+		if new(big.Int).GCD(nil, nil, n, phi).Cmp(one) != 0 {
+			continue
+		}
+		return &PaillierPrivateKey{PaillierPublicKey: PaillierPublicKey{N: n}, P: p, Q: q, Phi: phi}, nil
+	}
+}
+
+// blumPrime returns a prime p = 3 mod 4.
+func blumPrime(random io.Reader, bits int) (*big.Int, error) {
+	for {
+		p, err := rand.Prime(random, bits)
+		if err != nil {
+			return nil, err
+		}
+		if p.Bit(1) == 1 {
+			return p, nil
+		}
+	}
+}
+
+func (pk *PaillierPublicKey) nSquare() *big.Int {
+	return new(big.Int).Mul(pk.N, pk.N)
+}
+
+// Encrypt returns (1+N)^m * r^N mod N^2 for a random r.
+func (pk *PaillierPublicKey) Encrypt(random io.Reader, m *big.Int) (*big.Int, error) {
+	c, _, err := pk.encryptWithNonce(random, m)
+	return c, err
+}
+
+// encryptWithNonce is Encrypt returning r too, for the proofs about c.
+func (pk *PaillierPublicKey) encryptWithNonce(random io.Reader, m *big.Int) (*big.Int, *big.Int, error) {
+	if m.Sign() < 0 || m.Cmp(pk.N) >= 0 {
+		return nil, nil, errors.New("paillier plaintext out of range")
+	}
+	r, err := randomUnit(random, pk.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pk.encrypt(m, r), r, nil
+}
+
+// encrypt returns (1+N)^m * r^N mod N^2. m may be negative or exceed N, as
+// 1+N has order N.
+func (pk *PaillierPublicKey) encrypt(m, r *big.Int) *big.Int {
+	nSquare := pk.nSquare()
+	// (1+N)^m = 1 + mN mod N^2
+	gm := new(big.Int).Mod(m, pk.N)
+	gm.Mul(gm, pk.N).Add(gm, one)
+	rn := new(big.Int).Exp(r, pk.N, nSquare)
+	return gm.Mul(gm, rn).Mod(gm, nSquare)
+}
+
+// Add returns the encryption of the sum of the plaintexts of c1 and c2.
+func (pk *PaillierPublicKey) Add(c1, c2 *big.Int) *big.Int {
+	nSquare := pk.nSquare()
+	return new(big.Int).Mod(new(big.Int).Mul(c1, c2), nSquare)
+}
+
+// Mul returns the encryption of k times the plaintext of c.
+func (pk *PaillierPublicKey) Mul(c, k *big.Int) *big.Int {
+	return new(big.Int).Exp(c, k, pk.nSquare())
+}
+
+func (pk *PaillierPublicKey) validCiphertext(c *big.Int) bool {
+	if c == nil || c.Sign() <= 0 || c.Cmp(pk.nSquare()) >= 0 {
+		return false
+	}
+	return new(big.Int).GCD(nil, nil, c, pk.N).Cmp(one) == 0
+}
+
+// Decrypt returns L(c^phi mod N^2) * phi^-1 mod N.
+func (sk *PaillierPrivateKey) Decrypt(c *big.Int) (*big.Int, error) {
+	if !sk.validCiphertext(c) {
+		return nil, ErrInvalidCiphertext
+	}
+	u := new(big.Int).Exp(c, sk.Phi, sk.nSquare())
+	u.Sub(u, one).Div(u, sk.N)
+	mu := new(big.Int).ModInverse(sk.Phi, sk.N)
+	return u.Mul(u, mu).Mod(u, sk.N), nil
+}
+
+func randomUnit(random io.Reader, n *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(random, n)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() > 0 && new(big.Int).GCD(nil, nil, r, n).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
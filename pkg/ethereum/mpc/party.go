@@ -0,0 +1,333 @@
+package mpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultSignTimeout = 30 * time.Second
+
+	roundSignRequest = "sign/request"
+	sessionQueueSize = 64
+
+	// earlyTTL and maxEarlyPerParty bound the messages kept for ceremonies
+	// that haven't started yet.
+	earlyTTL         = DefaultSignTimeout
+	maxEarlyPerParty = 4 * sessionQueueSize
+)
+
+// SignRequest is sent by the party that starts a signing ceremony to the other
+// signers.
+type SignRequest struct {
+	Signers []PartyID     `json:"signers"`
+	Hash    hexutil.Bytes `json:"hash"`
+}
+
+// ApproveFunc decides whether a party joins a ceremony started by another
+// party. Returning an error aborts the ceremony for everyone.
+type ApproveFunc func(from PartyID, req *SignRequest) error
+
+// RejectAll is an ApproveFunc for parties that only start ceremonies.
+func RejectAll(from PartyID, req *SignRequest) error {
+	return errors.New("party doesn't co-sign")
+}
+
+// Party runs signing ceremonies for one key share. A single Run loop reads
+// the transport and routes messages to the ceremonies in progress, so the party
+// can start ceremonies with Sign and join ceremonies started by others.
+type Party struct {
+	share     *KeyShare
+	transport Transport
+	approve   ApproveFunc
+
+	mu       sync.Mutex
+	sessions map[string]chan *Message
+	early    map[string]*earlyMessages
+	// earlyCount is the number of early messages kept per sender.
+	earlyCount map[PartyID]int
+}
+
+// earlyMessages are the messages of a ceremony that hasn't started yet.
+type earlyMessages struct {
+	received time.Time
+	messages []*Message
+}
+
+// NewParty returns a party for share. approve is required and decides which
+// ceremonies requested by the other parties it joins; see RejectAll.
+func NewParty(share *KeyShare, transport Transport, approve ApproveFunc) (*Party, error) {
+	if approve == nil {
+		return nil, errors.Wrap(ErrInvalidConfig, "approve is required")
+	}
+	return &Party{
+		share:      share,
+		transport:  transport,
+		approve:    approve,
+		sessions:   make(map[string]chan *Message),
+		early:      make(map[string]*earlyMessages),
+		earlyCount: make(map[PartyID]int),
+	}, nil
+}
+
+func (p *Party) ID() PartyID {
+	return p.share.ID
+}
+
+// Run routes incoming messages until ctx is done.
+func (p *Party) Run(ctx context.Context) error {
+	for {
+		msg, err := p.transport.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if msg.Round == roundSignRequest {
+			queue, ok := p.register(msg.Session)
+			if !ok {
+				zap.S().Warnw("duplicate mpc sign request", "session", msg.Session, "from", msg.From)
+				continue
+			}
+			go p.cosign(ctx, msg, queue)
+			continue
+		}
+		p.deliver(msg)
+	}
+}
+
+func (p *Party) register(session string) (chan *Message, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.sessions[session]; ok {
+		return nil, false
+	}
+	queue := make(chan *Message, sessionQueueSize)
+	p.sessions[session] = queue
+	if early, ok := p.early[session]; ok {
+		for _, msg := range early.messages {
+			select {
+			case queue <- msg:
+			default:
+			}
+		}
+		p.dropEarly(session)
+	}
+	return queue, true
+}
+
+func (p *Party) unregister(session string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sessions, session)
+	p.dropEarly(session)
+}
+
+// deliver hands msg to its ceremony, or keeps it until the ceremony starts.
+func (p *Party) deliver(msg *Message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queue, ok := p.sessions[msg.Session]
+	if !ok {
+		p.keepEarly(msg)
+		return
+	}
+	select {
+	case queue <- msg:
+	default:
+		zap.S().Warnw("mpc session queue full, dropping message", "session", msg.Session, "round", msg.Round)
+	}
+}
+
+// keepEarly keeps msg until its ceremony starts, within the limits on early
+// messages. p.mu must be held.
+func (p *Party) keepEarly(msg *Message) {
+	// late aborts of ceremonies that are already over
+	if msg.Round == roundAbort {
+		return
+	}
+	if _, ok := p.share.PublicShares[msg.From]; !ok {
+		return
+	}
+
+	now := time.Now()
+	for session, early := range p.early {
+		if now.Sub(early.received) > earlyTTL {
+			p.dropEarly(session)
+		}
+	}
+	if p.earlyCount[msg.From] >= maxEarlyPerParty {
+		zap.S().Warnw("too many early mpc messages, dropping message", "session", msg.Session, "from", msg.From)
+		return
+	}
+
+	early, ok := p.early[msg.Session]
+	if !ok {
+		early = &earlyMessages{received: now}
+		p.early[msg.Session] = early
+	}
+	if len(early.messages) >= sessionQueueSize {
+		return
+	}
+	early.messages = append(early.messages, msg)
+	p.earlyCount[msg.From]++
+}
+
+// dropEarly forgets the early messages of session. p.mu must be held.
+func (p *Party) dropEarly(session string) {
+	early, ok := p.early[session]
+	if !ok {
+		return
+	}
+	for _, msg := range early.messages {
+		if p.earlyCount[msg.From]--; p.earlyCount[msg.From] <= 0 {
+			delete(p.earlyCount, msg.From)
+		}
+	}
+	delete(p.early, session)
+}
+
+func receiveFrom(queue chan *Message) func(ctx context.Context) (*Message, error) {
+	return func(ctx context.Context) (*Message, error) {
+		select {
+		case msg := <-queue:
+			return msg, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Sign starts a ceremony with signers, which must include this party, and
+// returns the signature of hash with V in {0, 1}. Run must be running.
+func (p *Party) Sign(ctx context.Context, signers []PartyID, hash []byte) ([]byte, error) {
+	session, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	queue, _ := p.register(session)
+	defer p.unregister(session)
+
+	s, err := newSignSession(p.share, session, signers, p.transport, receiveFrom(queue))
+	if err != nil {
+		return nil, err
+	}
+	req := SignRequest{Signers: s.signers, Hash: hash}
+	if err := s.broadcast(ctx, roundSignRequest, req); err != nil {
+		return nil, err
+	}
+	return s.sign(ctx, hash)
+}
+
+func (p *Party) cosign(ctx context.Context, msg *Message, queue chan *Message) {
+	defer p.unregister(msg.Session)
+
+	err := p.joinCeremony(ctx, msg, queue)
+	if err != nil {
+		zap.S().Warnw("mpc sign ceremony failed", "session", msg.Session, "from", msg.From, "error", err)
+	}
+}
+
+func (p *Party) joinCeremony(ctx context.Context, msg *Message, queue chan *Message) error {
+	var req SignRequest
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return errors.Wrap(ErrInvalidMessage, err.Error())
+	}
+
+	s, err := newSignSession(p.share, msg.Session, req.Signers, p.transport, receiveFrom(queue))
+	if err == nil {
+		err = p.approve(msg.From, &req)
+	}
+	if err != nil {
+		abort(p.transport, msg.Session, req.Signers, p.share.ID, err)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultSignTimeout)
+	defer cancel()
+	_, err = s.sign(ctx, req.Hash)
+	return err
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Signer signs with a threshold key by running a ceremony with a fixed set of
+// co-signers for every signature.
+type Signer struct {
+	party   *Party
+	signers []PartyID
+	address common.Address
+	timeout time.Duration
+}
+
+var _ ethereum.Signer = (*Signer)(nil)
+
+// NewSigner returns a signer starting ceremonies from party with signers, which
+// must include the party and reach the threshold.
+func NewSigner(party *Party, signers []PartyID) (*Signer, error) {
+	if err := validateParties(signers, party.ID()); err != nil {
+		return nil, err
+	}
+	if len(signers) < party.share.Threshold {
+		return nil, errors.Wrapf(ErrNotEnoughSigners, "%d signers, threshold is %d", len(signers), party.share.Threshold)
+	}
+	address, err := party.share.Address()
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		party:   party,
+		signers: append([]PartyID(nil), signers...),
+		address: address,
+		timeout: DefaultSignTimeout,
+	}, nil
+}
+
+// SetTimeout bounds each signing ceremony.
+func (s *Signer) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.party.Sign(ctx, s.signers, payload)
+}
+
+func (s *Signer) SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	return ethereum.SignTxWithHash(s, chainID, tx)
+}
+
+func (s *Signer) SignText(message []byte) ([]byte, error) {
+	return ethereum.SignTextWithHash(s, message)
+}
+
+func (s *Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return ethereum.SignTypedDataWithHash(s, typedData)
+}
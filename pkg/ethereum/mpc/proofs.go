@@ -0,0 +1,673 @@
+package mpc
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// The zero-knowledge proofs of CGGMP21 (Canetti, Gennaro, Goldfeder,
+// Makriyannis, Peled: "UC Non-Interactive, Proactive, Threshold ECDSA with
+// Identifiable Aborts"), made non-interactive with Fiat-Shamir. Range proofs
+// commit to their witnesses with the verifier's ring-Pedersen parameters, so
+// they are computed for each verifier.
+
+const (
+	// rangeBits (l) bounds the secrets whose range is proven, maskBits (l')
+	// the MtA masks and slackBits (epsilon) the slack of the range proofs.
+	rangeBits = 256
+	maskBits  = 5 * rangeBits
+	slackBits = 2 * rangeBits
+
+	// statisticalRounds is the number of repetitions of the proofs with
+	// binary or unstructured challenges, for a soundness error of 2^-80.
+	statisticalRounds = 80
+)
+
+var ErrInvalidProof = errors.New("invalid zero-knowledge proof")
+
+// transcript derives the Fiat-Shamir challenges of a proof from its context,
+// statement and first message.
+type transcript struct {
+	state crypto.KeccakState
+}
+
+func newTranscript(label, session string, prover, verifier PartyID) *transcript {
+	t := &transcript{state: crypto.NewKeccakState()}
+	t.writeBytes([]byte(label))
+	t.writeBytes([]byte(session))
+	t.writeBytes(binary.BigEndian.AppendUint64(nil, uint64(prover)))
+	t.writeBytes(binary.BigEndian.AppendUint64(nil, uint64(verifier)))
+	return t
+}
+
+func (t *transcript) writeBytes(b []byte) {
+	t.state.Write(binary.BigEndian.AppendUint64(nil, uint64(len(b))))
+	t.state.Write(b)
+}
+
+func (t *transcript) writeInts(values ...*big.Int) {
+	for _, v := range values {
+		switch {
+		case v == nil:
+			t.writeBytes(nil)
+		case v.Sign() < 0:
+			t.writeBytes(append([]byte{1}, v.Bytes()...))
+		default:
+			t.writeBytes(append([]byte{0}, v.Bytes()...))
+		}
+	}
+}
+
+func (t *transcript) writePoints(points ...point) {
+	for _, p := range points {
+		t.writeBytes(p.bytes())
+	}
+}
+
+// modulo returns a challenge in [0, n). Nothing can be written afterwards.
+func (t *transcript) modulo(n *big.Int) *big.Int {
+	buf := make([]byte, (n.BitLen()+128+7)/8)
+	t.state.Read(buf)
+	return new(big.Int).Mod(new(big.Int).SetBytes(buf), n)
+}
+
+// challenge returns a challenge in [0, q).
+func (t *transcript) challenge() *big.Int {
+	return t.modulo(curveN)
+}
+
+func (t *transcript) bits(count int) []uint {
+	buf := make([]byte, (count+7)/8)
+	t.state.Read(buf)
+	bits := make([]uint, count)
+	for i := range bits {
+		bits[i] = uint(buf[i/8]>>(i%8)) & 1
+	}
+	return bits
+}
+
+func pow2(bits int) *big.Int {
+	return new(big.Int).Lsh(one, uint(bits))
+}
+
+// randomSymmetric returns a uniform integer in [-bound, bound].
+func randomSymmetric(bound *big.Int) (*big.Int, error) {
+	x, err := rand.Int(rand.Reader, new(big.Int).Add(new(big.Int).Lsh(bound, 1), one))
+	if err != nil {
+		return nil, err
+	}
+	return x.Sub(x, bound), nil
+}
+
+// inRange reports whether |x| <= bound.
+func inRange(x, bound *big.Int) bool {
+	return x != nil && new(big.Int).Abs(x).Cmp(bound) <= 0
+}
+
+// isUnit reports whether x is a reduced unit modulo n.
+func isUnit(x, n *big.Int) bool {
+	return x != nil && x.Sign() > 0 && x.Cmp(n) < 0 && new(big.Int).GCD(nil, nil, x, n).Cmp(one) == 0
+}
+
+func notNil(values ...*big.Int) bool {
+	for _, v := range values {
+		if v == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// expMod returns x^e mod n for an e of any sign. x must be a unit.
+func expMod(x, e, n *big.Int) *big.Int {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(x, e, n)
+	}
+	inv := new(big.Int).ModInverse(x, n)
+	if inv == nil {
+		return new(big.Int)
+	}
+	return inv.Exp(inv, new(big.Int).Neg(e), n)
+}
+
+// mulMod returns the product of values mod n.
+func mulMod(n *big.Int, values ...*big.Int) *big.Int {
+	result := big.NewInt(1)
+	for _, v := range values {
+		result.Mul(result, v).Mod(result, n)
+	}
+	return result
+}
+
+// PedersenParams are ring-Pedersen parameters: s and t are quadratic residues
+// modulo N with s = t^lambda for a lambda known to the owner only. Range
+// proofs commit to a witness x with s^x * t^r mod N.
+type PedersenParams struct {
+	N *big.Int `json:"n"`
+	S *big.Int `json:"s"`
+	T *big.Int `json:"t"`
+}
+
+// newPedersenParams derives parameters on the modulus of sk and returns them
+// with lambda.
+func newPedersenParams(sk *PaillierPrivateKey) (*PedersenParams, *big.Int, error) {
+	tau, err := randomUnit(rand.Reader, sk.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	lambda, err := rand.Int(rand.Reader, sk.Phi)
+	if err != nil {
+		return nil, nil, err
+	}
+	t := new(big.Int).Exp(tau, big.NewInt(2), sk.N)
+	s := new(big.Int).Exp(t, lambda, sk.N)
+	return &PedersenParams{N: sk.N, S: s, T: t}, lambda, nil
+}
+
+func (p *PedersenParams) valid() bool {
+	return p != nil && p.N != nil && p.N.Sign() > 0 &&
+		isUnit(p.S, p.N) && isUnit(p.T, p.N) && p.S.Cmp(one) != 0 && p.T.Cmp(one) != 0
+}
+
+func (p *PedersenParams) commit(x, r *big.Int) *big.Int {
+	return mulMod(p.N, expMod(p.S, x, p.N), expMod(p.T, r, p.N))
+}
+
+func (p *PedersenParams) write(t *transcript) {
+	t.writeInts(p.N, p.S, p.T)
+}
+
+// modProof proves that N is a Paillier-Blum modulus: the product of two primes
+// p = q = 3 mod 4 with gcd(N, phi(N)) = 1 (Πmod).
+type modProof struct {
+	W *big.Int   `json:"w"`
+	X []*big.Int `json:"x"`
+	A []uint     `json:"a"`
+	B []uint     `json:"b"`
+	Z []*big.Int `json:"z"`
+}
+
+func proveMod(t *transcript, sk *PaillierPrivateKey) (*modProof, error) {
+	n := sk.N
+	// w has Jacobi symbol -1, so exactly one of y, -y, wy, -wy is a square
+	var w *big.Int
+	for {
+		var err error
+		if w, err = randomUnit(rand.Reader, n); err != nil {
+			return nil, err
+		}
+		if big.Jacobi(w, n) == -1 {
+			break
+		}
+	}
+	t.writeInts(n, w)
+
+	nInverse := new(big.Int).ModInverse(n, sk.Phi)
+	proof := &modProof{W: w}
+	for i := 0; i < statisticalRounds; i++ {
+		y := t.modulo(n)
+		found := false
+		for _, ab := range [][2]uint{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+			square := modAdjust(y, w, ab[0], ab[1], n)
+			if big.Jacobi(square, sk.P) == 1 && big.Jacobi(square, sk.Q) == 1 {
+				proof.X = append(proof.X, fourthRoot(square, sk.P, sk.Q))
+				proof.A = append(proof.A, ab[0])
+				proof.B = append(proof.B, ab[1])
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("challenge is not a unit")
+		}
+		proof.Z = append(proof.Z, new(big.Int).Exp(y, nInverse, n))
+	}
+	return proof, nil
+}
+
+func (proof *modProof) verify(t *transcript, n *big.Int) bool {
+	if proof == nil || n == nil || n.Bit(0) == 0 || n.ProbablyPrime(20) || !isUnit(proof.W, n) {
+		return false
+	}
+	if len(proof.X) != statisticalRounds || len(proof.A) != statisticalRounds ||
+		len(proof.B) != statisticalRounds || len(proof.Z) != statisticalRounds {
+		return false
+	}
+	t.writeInts(n, proof.W)
+	four := big.NewInt(4)
+	for i := 0; i < statisticalRounds; i++ {
+		y := t.modulo(n)
+		x, z := proof.X[i], proof.Z[i]
+		if !notNil(x, z) || proof.A[i] > 1 || proof.B[i] > 1 {
+			return false
+		}
+		if new(big.Int).Exp(z, n, n).Cmp(y) != 0 {
+			return false
+		}
+		if new(big.Int).Exp(x, four, n).Cmp(modAdjust(y, proof.W, proof.A[i], proof.B[i], n)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// modAdjust returns (-1)^a * w^b * y mod n.
+func modAdjust(y, w *big.Int, a, b uint, n *big.Int) *big.Int {
+	result := new(big.Int).Set(y)
+	if a == 1 {
+		result.Sub(n, result)
+	}
+	if b == 1 {
+		result.Mul(result, w).Mod(result, n)
+	}
+	return result
+}
+
+// fourthRoot returns the fourth root of y modulo the Blum primes p and q that
+// is itself a square. y must be a square modulo both.
+func fourthRoot(y, p, q *big.Int) *big.Int {
+	root := func(prime *big.Int) *big.Int {
+		// y^((p+1)/4) is the square root that is a square
+		e := new(big.Int).Rsh(new(big.Int).Add(prime, one), 2)
+		e.Mul(e, e)
+		return new(big.Int).Exp(y, e, prime)
+	}
+	xp, xq := root(p), root(q)
+	// CRT: x = xp + p * ((xq - xp) * p^-1 mod q)
+	h := new(big.Int).Sub(xq, xp)
+	h.Mul(h, new(big.Int).ModInverse(p, q)).Mod(h, q)
+	return h.Mul(h, p).Add(h, xp)
+}
+
+// prmProof proves that s is in the group generated by t, so that ring-Pedersen
+// commitments hide the committed value (Πprm).
+type prmProof struct {
+	A []*big.Int `json:"a"`
+	Z []*big.Int `json:"z"`
+}
+
+func provePrm(t *transcript, params *PedersenParams, lambda, phi *big.Int) (*prmProof, error) {
+	a := make([]*big.Int, statisticalRounds)
+	proof := &prmProof{A: make([]*big.Int, statisticalRounds), Z: make([]*big.Int, statisticalRounds)}
+	for i := range a {
+		var err error
+		if a[i], err = rand.Int(rand.Reader, phi); err != nil {
+			return nil, err
+		}
+		proof.A[i] = new(big.Int).Exp(params.T, a[i], params.N)
+	}
+	params.write(t)
+	t.writeInts(proof.A...)
+	for i, e := range t.bits(statisticalRounds) {
+		proof.Z[i] = new(big.Int).Add(a[i], new(big.Int).Mul(big.NewInt(int64(e)), lambda))
+		proof.Z[i].Mod(proof.Z[i], phi)
+	}
+	return proof, nil
+}
+
+func (proof *prmProof) verify(t *transcript, params *PedersenParams) bool {
+	if proof == nil || !params.valid() || len(proof.A) != statisticalRounds || len(proof.Z) != statisticalRounds {
+		return false
+	}
+	for i := range proof.A {
+		if !isUnit(proof.A[i], params.N) || proof.Z[i] == nil || proof.Z[i].Sign() < 0 {
+			return false
+		}
+	}
+	params.write(t)
+	t.writeInts(proof.A...)
+	for i, e := range t.bits(statisticalRounds) {
+		expected := proof.A[i]
+		if e == 1 {
+			expected = mulMod(params.N, expected, params.S)
+		}
+		if new(big.Int).Exp(params.T, proof.Z[i], params.N).Cmp(expected) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// facProof proves to the owner of the ring-Pedersen parameters that the
+// Paillier modulus N0 = p*q has no factor below 2^l (Πfac).
+type facProof struct {
+	P     *big.Int `json:"p"`
+	Q     *big.Int `json:"q"`
+	A     *big.Int `json:"a"`
+	B     *big.Int `json:"b"`
+	T     *big.Int `json:"t"`
+	Sigma *big.Int `json:"sigma"`
+	Z1    *big.Int `json:"z1"`
+	Z2    *big.Int `json:"z2"`
+	W1    *big.Int `json:"w1"`
+	W2    *big.Int `json:"w2"`
+	V     *big.Int `json:"v"`
+}
+
+func facBound(n0 *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).Sqrt(n0), pow2(rangeBits+slackBits))
+}
+
+func proveFac(t *transcript, sk *PaillierPrivateKey, verifier *PedersenParams) (*facProof, error) {
+	n0, nHat := sk.N, verifier.N
+	muBound := new(big.Int).Mul(pow2(rangeBits), nHat)
+	sigmaBound := new(big.Int).Mul(muBound, n0)
+	rBound := new(big.Int).Mul(new(big.Int).Mul(pow2(rangeBits+slackBits), n0), nHat)
+	xBound := new(big.Int).Mul(pow2(rangeBits+slackBits), nHat)
+
+	var alpha, beta, mu, nu, sigma, r, x, y *big.Int
+	for _, sample := range []struct {
+		v     **big.Int
+		bound *big.Int
+	}{
+		{&alpha, facBound(n0)}, {&beta, facBound(n0)},
+		{&mu, muBound}, {&nu, muBound},
+		{&sigma, sigmaBound}, {&r, rBound},
+		{&x, xBound}, {&y, xBound},
+	} {
+		var err error
+		if *sample.v, err = randomSymmetric(sample.bound); err != nil {
+			return nil, err
+		}
+	}
+
+	proof := &facProof{
+		P:     verifier.commit(sk.P, mu),
+		Q:     verifier.commit(sk.Q, nu),
+		A:     verifier.commit(alpha, x),
+		B:     verifier.commit(beta, y),
+		Sigma: sigma,
+	}
+	proof.T = mulMod(nHat, expMod(proof.Q, alpha, nHat), expMod(verifier.T, r, nHat))
+
+	e := proof.challenge(t, n0, verifier)
+	// sigmaHat = sigma - nu*p makes R = Q^p * t^sigmaHat = s^N0 * t^sigma
+	sigmaHat := new(big.Int).Sub(sigma, new(big.Int).Mul(nu, sk.P))
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, sk.P))
+	proof.Z2 = new(big.Int).Add(beta, new(big.Int).Mul(e, sk.Q))
+	proof.W1 = new(big.Int).Add(x, new(big.Int).Mul(e, mu))
+	proof.W2 = new(big.Int).Add(y, new(big.Int).Mul(e, nu))
+	proof.V = new(big.Int).Add(r, new(big.Int).Mul(e, sigmaHat))
+	return proof, nil
+}
+
+func (proof *facProof) challenge(t *transcript, n0 *big.Int, verifier *PedersenParams) *big.Int {
+	t.writeInts(n0)
+	verifier.write(t)
+	t.writeInts(proof.P, proof.Q, proof.A, proof.B, proof.T, proof.Sigma)
+	return t.challenge()
+}
+
+func (proof *facProof) verify(t *transcript, n0 *big.Int, verifier *PedersenParams) bool {
+	if proof == nil || n0 == nil || !verifier.valid() ||
+		!notNil(proof.Sigma, proof.Z1, proof.Z2, proof.W1, proof.W2, proof.V) {
+		return false
+	}
+	nHat := verifier.N
+	for _, v := range []*big.Int{proof.P, proof.Q, proof.A, proof.B, proof.T} {
+		if !isUnit(v, nHat) {
+			return false
+		}
+	}
+	if !inRange(proof.Z1, facBound(n0)) || !inRange(proof.Z2, facBound(n0)) {
+		return false
+	}
+
+	e := proof.challenge(t, n0, verifier)
+	if verifier.commit(proof.Z1, proof.W1).Cmp(mulMod(nHat, proof.A, expMod(proof.P, e, nHat))) != 0 {
+		return false
+	}
+	if verifier.commit(proof.Z2, proof.W2).Cmp(mulMod(nHat, proof.B, expMod(proof.Q, e, nHat))) != 0 {
+		return false
+	}
+	R := verifier.commit(n0, proof.Sigma)
+	left := mulMod(nHat, expMod(proof.Q, proof.Z1, nHat), expMod(verifier.T, proof.V, nHat))
+	return left.Cmp(mulMod(nHat, proof.T, expMod(R, e, nHat))) == 0
+}
+
+// encProof proves that the Paillier ciphertext C encrypts some x in +-2^l
+// under the prover's key (Πenc). With a base point g it also proves that
+// X = x*g (Πlog*).
+type encProof struct {
+	S  *big.Int      `json:"s"`
+	A  *big.Int      `json:"a"`
+	D  *big.Int      `json:"d"`
+	Y  hexutil.Bytes `json:"y,omitempty"`
+	Z1 *big.Int      `json:"z1"`
+	Z2 *big.Int      `json:"z2"`
+	Z3 *big.Int      `json:"z3"`
+}
+
+// proveEnc proves that c = Enc(x; rho) under pk, and X = x*g if g isn't nil.
+func proveEnc(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c, x, rho *big.Int, g *point) (*encProof, error) {
+	nHat := verifier.N
+	alpha, err := randomSymmetric(pow2(rangeBits + slackBits))
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSymmetric(new(big.Int).Mul(pow2(rangeBits), nHat))
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSymmetric(new(big.Int).Mul(pow2(rangeBits+slackBits), nHat))
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(rand.Reader, pk.N)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &encProof{
+		S: verifier.commit(x, mu),
+		A: pk.encrypt(alpha, r),
+		D: verifier.commit(alpha, gamma),
+	}
+	var X *point
+	if g != nil {
+		proof.Y = g.mul(alpha).bytes()
+		p := g.mul(x)
+		X = &p
+	}
+
+	e := proof.challenge(t, pk, verifier, c, g, X)
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	proof.Z2 = mulMod(pk.N, r, new(big.Int).Exp(rho, e, pk.N))
+	proof.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+	return proof, nil
+}
+
+func (proof *encProof) challenge(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c *big.Int, g, X *point) *big.Int {
+	t.writeInts(pk.N, c)
+	verifier.write(t)
+	if g != nil {
+		t.writePoints(*g, *X)
+		t.writeBytes(proof.Y)
+	}
+	t.writeInts(proof.S, proof.A, proof.D)
+	return t.challenge()
+}
+
+// verify checks the proof for c, and for X = x*g if g isn't nil.
+func (proof *encProof) verify(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c *big.Int, g, X *point) bool {
+	if proof == nil || !verifier.valid() || !pk.validCiphertext(c) || !pk.validCiphertext(proof.A) ||
+		!isUnit(proof.S, verifier.N) || !isUnit(proof.D, verifier.N) || !isUnit(proof.Z2, pk.N) || proof.Z3 == nil {
+		return false
+	}
+	if !inRange(proof.Z1, pow2(rangeBits+slackBits)) {
+		return false
+	}
+	var Y point
+	if g != nil {
+		var err error
+		if Y, err = decodePoint(proof.Y); err != nil {
+			return false
+		}
+	}
+
+	e := proof.challenge(t, pk, verifier, c, g, X)
+	nSquare := pk.nSquare()
+	if pk.encrypt(proof.Z1, proof.Z2).Cmp(mulMod(nSquare, proof.A, new(big.Int).Exp(c, e, nSquare))) != 0 {
+		return false
+	}
+	if verifier.commit(proof.Z1, proof.Z3).Cmp(mulMod(verifier.N, proof.D, expMod(proof.S, e, verifier.N))) != 0 {
+		return false
+	}
+	if g != nil && !g.mul(proof.Z1).equal(Y.add(X.mul(e))) {
+		return false
+	}
+	return true
+}
+
+// affProof proves to Alice that Bob computed D = C^x * Enc(y; rho) under her
+// key for her ciphertext C, with x in +-2^l and y in +-2^l'. With X it also
+// proves X = x*G (Πaff-g).
+type affProof struct {
+	A  *big.Int      `json:"a"`
+	Bx hexutil.Bytes `json:"bx,omitempty"`
+	E  *big.Int      `json:"e"`
+	S  *big.Int      `json:"s"`
+	F  *big.Int      `json:"f"`
+	T  *big.Int      `json:"t"`
+	Z1 *big.Int      `json:"z1"`
+	Z2 *big.Int      `json:"z2"`
+	Z3 *big.Int      `json:"z3"`
+	Z4 *big.Int      `json:"z4"`
+	W  *big.Int      `json:"w"`
+}
+
+func proveAff(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c, d, x, y, rho *big.Int, X *point) (*affProof, error) {
+	nHat := verifier.N
+	var alpha, beta, gamma, m, delta, mu *big.Int
+	for _, sample := range []struct {
+		v     **big.Int
+		bound *big.Int
+	}{
+		{&alpha, pow2(rangeBits + slackBits)},
+		{&beta, pow2(maskBits + slackBits)},
+		{&gamma, new(big.Int).Mul(pow2(rangeBits+slackBits), nHat)},
+		{&m, new(big.Int).Mul(pow2(rangeBits), nHat)},
+		{&delta, new(big.Int).Mul(pow2(rangeBits+slackBits), nHat)},
+		{&mu, new(big.Int).Mul(pow2(rangeBits), nHat)},
+	} {
+		var err error
+		if *sample.v, err = randomSymmetric(sample.bound); err != nil {
+			return nil, err
+		}
+	}
+	r, err := randomUnit(rand.Reader, pk.N)
+	if err != nil {
+		return nil, err
+	}
+
+	nSquare := pk.nSquare()
+	proof := &affProof{
+		A: mulMod(nSquare, expMod(c, alpha, nSquare), pk.encrypt(beta, r)),
+		E: verifier.commit(alpha, gamma),
+		S: verifier.commit(x, m),
+		F: verifier.commit(beta, delta),
+		T: verifier.commit(y, mu),
+	}
+	if X != nil {
+		proof.Bx = baseMul(alpha).bytes()
+	}
+
+	e := proof.challenge(t, pk, verifier, c, d, X)
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	proof.Z2 = new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	proof.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	proof.Z4 = new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	proof.W = mulMod(pk.N, r, new(big.Int).Exp(rho, e, pk.N))
+	return proof, nil
+}
+
+func (proof *affProof) challenge(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c, d *big.Int, X *point) *big.Int {
+	t.writeInts(pk.N, c, d)
+	verifier.write(t)
+	if X != nil {
+		t.writePoints(*X)
+		t.writeBytes(proof.Bx)
+	}
+	t.writeInts(proof.A, proof.E, proof.S, proof.F, proof.T)
+	return t.challenge()
+}
+
+func (proof *affProof) verify(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, c, d *big.Int, X *point) bool {
+	if proof == nil || !verifier.valid() || !pk.validCiphertext(c) || !pk.validCiphertext(d) ||
+		!pk.validCiphertext(proof.A) || !isUnit(proof.W, pk.N) || !notNil(proof.Z3, proof.Z4) {
+		return false
+	}
+	for _, v := range []*big.Int{proof.E, proof.S, proof.F, proof.T} {
+		if !isUnit(v, verifier.N) {
+			return false
+		}
+	}
+	if !inRange(proof.Z1, pow2(rangeBits+slackBits)) || !inRange(proof.Z2, pow2(maskBits+slackBits)) {
+		return false
+	}
+	var Bx point
+	if X != nil {
+		var err error
+		if Bx, err = decodePoint(proof.Bx); err != nil {
+			return false
+		}
+	}
+
+	e := proof.challenge(t, pk, verifier, c, d, X)
+	nSquare, nHat := pk.nSquare(), verifier.N
+	left := mulMod(nSquare, expMod(c, proof.Z1, nSquare), pk.encrypt(proof.Z2, proof.W))
+	if left.Cmp(mulMod(nSquare, proof.A, new(big.Int).Exp(d, e, nSquare))) != 0 {
+		return false
+	}
+	if verifier.commit(proof.Z1, proof.Z3).Cmp(mulMod(nHat, proof.E, expMod(proof.S, e, nHat))) != 0 {
+		return false
+	}
+	if verifier.commit(proof.Z2, proof.Z4).Cmp(mulMod(nHat, proof.F, expMod(proof.T, e, nHat))) != 0 {
+		return false
+	}
+	if X != nil && !baseMul(proof.Z1).equal(Bx.add(X.mul(e))) {
+		return false
+	}
+	return true
+}
+
+// schnorrProof proves knowledge of x with X = x*G.
+type schnorrProof struct {
+	A hexutil.Bytes `json:"a"`
+	Z *big.Int      `json:"z"`
+}
+
+func proveSchnorr(t *transcript, x *big.Int) (*schnorrProof, error) {
+	a, err := randomScalar(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	defer a.SetInt64(0)
+	proof := &schnorrProof{A: baseMul(a).bytes()}
+	e := proof.challenge(t, baseMul(x))
+	proof.Z = modN(new(big.Int).Add(a, new(big.Int).Mul(e, x)))
+	return proof, nil
+}
+
+func (proof *schnorrProof) challenge(t *transcript, X point) *big.Int {
+	t.writePoints(X)
+	t.writeBytes(proof.A)
+	return t.challenge()
+}
+
+func (proof *schnorrProof) verify(t *transcript, X point) bool {
+	if proof == nil || proof.Z == nil {
+		return false
+	}
+	A, err := decodePoint(proof.A)
+	if err != nil {
+		return false
+	}
+	e := proof.challenge(t, X)
+	return baseMul(proof.Z).equal(A.add(X.mul(e)))
+}
@@ -0,0 +1,407 @@
+package mpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	roundSignCommit  = "sign/commit"
+	roundSignMtA     = "sign/mta"
+	roundSignDelta   = "sign/delta"
+	roundSignReveal  = "sign/reveal"
+	roundSignCheck   = "sign/check"
+	roundSignPartial = "sign/partial"
+)
+
+var ErrNotEnoughSigners = errors.New("not enough signers")
+
+// maskBound bounds the additive masks of MtA. It hides products of two
+// scalars statistically while keeping product + mask far below N.
+var maskBound = pow2(maskBits)
+
+type signCommit struct {
+	// Commitment is keccak256(session || id || Gamma || blind).
+	Commitment hexutil.Bytes `json:"commitment"`
+	// EncK is k encrypted under the sender's Paillier key, EncProof shows
+	// the recipient that k is in range.
+	EncK     *big.Int  `json:"encK"`
+	EncProof *encProof `json:"encProof"`
+}
+
+type signMtA struct {
+	// Gamma is Enc(k_to * gamma_from + mask), W is Enc(k_to * w_from + mask).
+	Gamma *big.Int `json:"gamma"`
+	W     *big.Int `json:"w"`
+	// GammaProof and WProof show that the factors and masks are in range,
+	// and WProof that w_from matches the sender's public key share.
+	GammaProof *affProof `json:"gammaProof"`
+	WProof     *affProof `json:"wProof"`
+}
+
+type signReveal struct {
+	Gamma hexutil.Bytes `json:"gamma"`
+	Blind hexutil.Bytes `json:"blind"`
+}
+
+type signCheck struct {
+	// KR is k_from*R, Proof shows it matches the sender's Enc(k).
+	KR    hexutil.Bytes `json:"kr"`
+	Proof *encProof     `json:"proof"`
+}
+
+// signSession is one party's state in a GG18-style signing ceremony. The
+// parties hold additive shares w_i of the key and pick nonce shares k_i and
+// blinding shares gamma_i; MtA over Paillier converts the products k*gamma and
+// k*x into additive shares without revealing the factors.
+//
+// As in CGGMP21, every party proves that its Enc(k_i) is in range and, as
+// Bob in MtA, that it used in-range factors and masks, and w_i for the
+// products with the key. Before revealing its share of s, every party checks
+// that the k_i*R of all signers sum to G, which fails unless R = k^-1*G for
+// the committed k_i. The final signature is checked against the group key.
+type signSession struct {
+	share     *KeyShare
+	session   string
+	signers   []PartyID
+	others    []PartyID
+	transport Transport
+	in        *inbox
+}
+
+func newSignSession(share *KeyShare, session string, signers []PartyID, transport Transport, receive func(ctx context.Context) (*Message, error)) (*signSession, error) {
+	if err := validateParties(signers, share.ID); err != nil {
+		return nil, err
+	}
+	if len(signers) < share.Threshold {
+		return nil, errors.Wrapf(ErrNotEnoughSigners, "%d signers, threshold is %d", len(signers), share.Threshold)
+	}
+	for _, id := range signers {
+		_, public := share.PublicShares[id]
+		_, paillier := share.PaillierKeys[id]
+		_, pedersen := share.Pedersen[id]
+		if !public || !paillier || !pedersen {
+			return nil, errors.Wrapf(ErrUnknownParty, "party %d", id)
+		}
+	}
+
+	signers = sortedParties(signers)
+	return &signSession{
+		share:     share,
+		session:   session,
+		signers:   signers,
+		others:    without(signers, share.ID),
+		transport: transport,
+		in:        &inbox{session: session, receive: receive},
+	}, nil
+}
+
+func (s *signSession) broadcast(ctx context.Context, round string, payload interface{}) error {
+	for _, id := range s.others {
+		if err := send(ctx, s.transport, s.session, round, id, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *signSession) collect(ctx context.Context, round string, v func(id PartyID) interface{}) error {
+	payloads, err := s.in.collect(ctx, round, s.others)
+	if err != nil {
+		return err
+	}
+	for _, id := range s.others {
+		if err := json.Unmarshal(payloads[id], v(id)); err != nil {
+			return errors.Wrapf(ErrInvalidMessage, "%s from party %d: %s", round, id, err)
+		}
+	}
+	return nil
+}
+
+func (s *signSession) commitment(id PartyID, gamma, blind []byte) []byte {
+	return crypto.Keccak256([]byte(s.session), big.NewInt(int64(id)).Bytes(), gamma, blind)
+}
+
+// sign runs the ceremony for hash and returns a 65-byte [R || S || V]
+// signature with low S and V in {0, 1}.
+func (s *signSession) sign(ctx context.Context, hash []byte) (sig []byte, err error) {
+	if len(hash) != 32 {
+		return nil, errors.Wrapf(ErrInvalidMessage, "hash must be 32 bytes, got %d", len(hash))
+	}
+	defer func() {
+		if err != nil {
+			abort(s.transport, s.session, s.signers, s.share.ID, err)
+		}
+	}()
+
+	self := s.share.ID
+	paillier := s.share.Paillier
+	w := modN(new(big.Int).Mul(lagrangeCoefficient(self, s.signers), s.share.Share))
+
+	// round 1: commit to Gamma_i = gamma_i*G and send Enc(k_i)
+	k, err := randomScalar(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomScalar(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		k.SetInt64(0)
+		gamma.SetInt64(0)
+		w.SetInt64(0)
+	}()
+
+	gammaPoint := baseMul(gamma).bytes()
+	blind := make([]byte, 32)
+	if _, err := rand.Read(blind); err != nil {
+		return nil, err
+	}
+	encK, rho, err := paillier.encryptWithNonce(rand.Reader, k)
+	if err != nil {
+		return nil, err
+	}
+	defer rho.SetInt64(0)
+	for _, id := range s.others {
+		proof, err := proveEnc(s.transcript(roundSignCommit, self, id), &paillier.PaillierPublicKey, s.share.Pedersen[id], encK, k, rho, nil)
+		if err != nil {
+			return nil, err
+		}
+		msg := signCommit{Commitment: s.commitment(self, gammaPoint, blind), EncK: encK, EncProof: proof}
+		if err := send(ctx, s.transport, s.session, roundSignCommit, id, msg); err != nil {
+			return nil, err
+		}
+	}
+	commits := make(map[PartyID]*signCommit, len(s.others))
+	if err := s.collect(ctx, roundSignCommit, func(id PartyID) interface{} {
+		commits[id] = new(signCommit)
+		return commits[id]
+	}); err != nil {
+		return nil, err
+	}
+	ownPedersen := s.share.Pedersen[self]
+	for _, id := range s.others {
+		if !commits[id].EncProof.verify(s.transcript(roundSignCommit, id, self), s.share.PaillierKeys[id], ownPedersen, commits[id].EncK, nil, nil) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: Enc(k)", id)
+		}
+	}
+
+	// round 2: MtA as Bob for every other party's k_j, with gamma_i and w_i
+	delta := modN(new(big.Int).Mul(k, gamma))
+	sigma := modN(new(big.Int).Mul(k, w))
+	defer sigma.SetInt64(0)
+	W := baseMul(w)
+	for _, id := range s.others {
+		pk, pedersen := s.share.PaillierKeys[id], s.share.Pedersen[id]
+		encGamma, beta, gammaProof, err := mta(s.transcript("sign/mta-gamma", self, id), pk, pedersen, commits[id].EncK, gamma, nil)
+		if err != nil {
+			return nil, err
+		}
+		encW, nu, wProof, err := mta(s.transcript("sign/mta-w", self, id), pk, pedersen, commits[id].EncK, w, &W)
+		if err != nil {
+			return nil, err
+		}
+		modN(delta.Add(delta, beta))
+		modN(sigma.Add(sigma, nu))
+
+		msg := signMtA{Gamma: encGamma, W: encW, GammaProof: gammaProof, WProof: wProof}
+		if err := send(ctx, s.transport, s.session, roundSignMtA, id, msg); err != nil {
+			return nil, err
+		}
+	}
+	mtas := make(map[PartyID]*signMtA, len(s.others))
+	if err := s.collect(ctx, roundSignMtA, func(id PartyID) interface{} {
+		mtas[id] = new(signMtA)
+		return mtas[id]
+	}); err != nil {
+		return nil, err
+	}
+	// as Alice: check the proofs and decrypt the shares of k_i*gamma_j and k_i*w_j
+	ownKey := &paillier.PaillierPublicKey
+	for _, id := range s.others {
+		theirW, err := s.weightedPublicShare(id)
+		if err != nil {
+			return nil, err
+		}
+		if !mtas[id].GammaProof.verify(s.transcript("sign/mta-gamma", id, self), ownKey, ownPedersen, encK, mtas[id].Gamma, nil) ||
+			!mtas[id].WProof.verify(s.transcript("sign/mta-w", id, self), ownKey, ownPedersen, encK, mtas[id].W, &theirW) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: MtA", id)
+		}
+		alpha, err := paillier.Decrypt(mtas[id].Gamma)
+		if err != nil {
+			return nil, errors.Wrapf(err, "party %d", id)
+		}
+		mu, err := paillier.Decrypt(mtas[id].W)
+		if err != nil {
+			return nil, errors.Wrapf(err, "party %d", id)
+		}
+		modN(delta.Add(delta, alpha))
+		modN(sigma.Add(sigma, mu))
+	}
+
+	// round 3: reveal delta_i; delta = k*gamma leaks nothing about k
+	if err := s.broadcast(ctx, roundSignDelta, delta); err != nil {
+		return nil, err
+	}
+	deltas := make(map[PartyID]*big.Int, len(s.others))
+	if err := s.collect(ctx, roundSignDelta, func(id PartyID) interface{} {
+		deltas[id] = new(big.Int)
+		return deltas[id]
+	}); err != nil {
+		return nil, err
+	}
+	for _, id := range s.others {
+		modN(delta.Add(delta, deltas[id]))
+	}
+	if delta.Sign() == 0 {
+		return nil, errors.Wrap(ErrAborted, "delta is zero")
+	}
+
+	// round 4: open the Gamma commitments, R = delta^-1 * sum(Gamma_j) = k^-1 * G
+	if err := s.broadcast(ctx, roundSignReveal, signReveal{Gamma: gammaPoint, Blind: blind}); err != nil {
+		return nil, err
+	}
+	reveals := make(map[PartyID]*signReveal, len(s.others))
+	if err := s.collect(ctx, roundSignReveal, func(id PartyID) interface{} {
+		reveals[id] = new(signReveal)
+		return reveals[id]
+	}); err != nil {
+		return nil, err
+	}
+	sum := baseMul(gamma)
+	for _, id := range s.others {
+		if !bytes.Equal(s.commitment(id, reveals[id].Gamma, reveals[id].Blind), commits[id].Commitment) {
+			return nil, errors.Wrapf(ErrInvalidMessage, "party %d opened another Gamma", id)
+		}
+		p, err := decodePoint(reveals[id].Gamma)
+		if err != nil {
+			return nil, errors.Wrapf(err, "party %d", id)
+		}
+		sum = sum.add(p)
+	}
+	if sum.isInfinity() {
+		return nil, errors.Wrap(ErrAborted, "Gamma is the point at infinity")
+	}
+	R := sum.mul(new(big.Int).ModInverse(delta, curveN))
+	r := new(big.Int).Mod(R.X, curveN)
+	if r.Sign() == 0 {
+		return nil, errors.Wrap(ErrAborted, "r is zero")
+	}
+
+	// check round: sum(k_j*R) = G iff R = k^-1*G for the k_j behind Enc(k_j)
+	kR := R.mul(k)
+	for _, id := range s.others {
+		proof, err := proveEnc(s.transcript(roundSignCheck, self, id), ownKey, s.share.Pedersen[id], encK, k, rho, &R)
+		if err != nil {
+			return nil, err
+		}
+		if err := send(ctx, s.transport, s.session, roundSignCheck, id, signCheck{KR: kR.bytes(), Proof: proof}); err != nil {
+			return nil, err
+		}
+	}
+	checks := make(map[PartyID]*signCheck, len(s.others))
+	if err := s.collect(ctx, roundSignCheck, func(id PartyID) interface{} {
+		checks[id] = new(signCheck)
+		return checks[id]
+	}); err != nil {
+		return nil, err
+	}
+	for _, id := range s.others {
+		theirs, err := decodePoint(checks[id].KR)
+		if err != nil {
+			return nil, errors.Wrapf(err, "party %d", id)
+		}
+		if !checks[id].Proof.verify(s.transcript(roundSignCheck, id, self), s.share.PaillierKeys[id], ownPedersen, commits[id].EncK, &R, &theirs) {
+			return nil, errors.Wrapf(ErrInvalidProof, "party %d: k*R", id)
+		}
+		kR = kR.add(theirs)
+	}
+	if !kR.equal(baseMul(one)) {
+		return nil, errors.Wrap(ErrAborted, "R doesn't match the committed nonces")
+	}
+
+	// round 5: s_i = m*k_i + r*sigma_i, s = k*(m + r*x)
+	m := new(big.Int).SetBytes(hash)
+	partial := modN(new(big.Int).Add(new(big.Int).Mul(m, k), new(big.Int).Mul(r, sigma)))
+	if err := s.broadcast(ctx, roundSignPartial, partial); err != nil {
+		return nil, err
+	}
+	partials := make(map[PartyID]*big.Int, len(s.others))
+	if err := s.collect(ctx, roundSignPartial, func(id PartyID) interface{} {
+		partials[id] = new(big.Int)
+		return partials[id]
+	}); err != nil {
+		return nil, err
+	}
+	for _, id := range s.others {
+		modN(partial.Add(partial, partials[id]))
+	}
+
+	sig = make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	partial.FillBytes(sig[32:64])
+	sig[crypto.RecoveryIDOffset] = byte(R.Y.Bit(0))
+	sig, err = ethereum.NormalizeSignature(sig, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "threshold signature doesn't verify")
+	}
+
+	publicKey, err := decodePoint(s.share.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	recovered, err := crypto.Ecrecover(hash, sig)
+	if err != nil || !bytes.Equal(recovered, crypto.FromECDSAPub(publicKeyECDSA(publicKey))) {
+		return nil, errors.Wrap(_types.ErrInvalidSignature, "threshold signature doesn't verify")
+	}
+	return sig, nil
+}
+
+// transcript returns the transcript of a proof in round from prover to
+// verifier.
+func (s *signSession) transcript(round string, prover, verifier PartyID) *transcript {
+	return newTranscript(round, s.session, prover, verifier)
+}
+
+// weightedPublicShare returns w_id*G, the public counterpart of the additive
+// share of party id among the signers.
+func (s *signSession) weightedPublicShare(id PartyID) (point, error) {
+	public, err := decodePoint(s.share.PublicShares[id])
+	if err != nil {
+		return point{}, err
+	}
+	return public.mul(lagrangeCoefficient(id, s.signers)), nil
+}
+
+// mta is Bob's side of the multiplicative-to-additive conversion: given
+// Enc(a) under Alice's key and his b, it returns Enc(a*b + mask) for Alice,
+// his additive share -mask, so that Alice's decryption plus his share is a*b,
+// and the proof for Alice that b and mask are in range and, if B isn't nil,
+// that B = b*G.
+func mta(t *transcript, pk *PaillierPublicKey, verifier *PedersenParams, encA, b *big.Int, B *point) (*big.Int, *big.Int, *affProof, error) {
+	mask, err := rand.Int(rand.Reader, maskBound)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	encMask, rho, err := pk.encryptWithNonce(rand.Reader, mask)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	encProduct := pk.Add(pk.Mul(encA, b), encMask)
+	proof, err := proveAff(t, pk, verifier, encA, encProduct, b, mask, rho, B)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	share := modN(new(big.Int).Neg(mask))
+	return encProduct, share, proof, nil
+}
@@ -0,0 +1,205 @@
+package mpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PartyID identifies a party. It is also the x-coordinate of its key share, so
+// it must be positive and unique.
+type PartyID int
+
+var (
+	ErrAborted        = errors.New("ceremony aborted")
+	ErrInvalidMessage = errors.New("invalid ceremony message")
+	ErrUnknownParty   = errors.New("unknown party")
+)
+
+// Message is a point-to-point protocol message. Session scopes it to one
+// ceremony and Round to one step of it.
+type Message struct {
+	Session string          `json:"session"`
+	Round   string          `json:"round"`
+	From    PartyID         `json:"from"`
+	To      PartyID         `json:"to"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Transport carries messages between parties. Implementations must
+// authenticate From and keep messages confidential, e.g. with mTLS between
+// parties, as key generation sends secret shares over it.
+type Transport interface {
+	Send(ctx context.Context, msg *Message) error
+	Receive(ctx context.Context) (*Message, error)
+}
+
+// MemoryNetwork connects parties running in the same process.
+type MemoryNetwork struct {
+	mu        sync.Mutex
+	mailboxes map[PartyID]*memoryMailbox
+}
+
+type memoryMailbox struct {
+	mu       sync.Mutex
+	messages []*Message
+	notify   chan struct{}
+}
+
+func NewMemoryNetwork(parties ...PartyID) *MemoryNetwork {
+	network := &MemoryNetwork{mailboxes: make(map[PartyID]*memoryMailbox, len(parties))}
+	for _, id := range parties {
+		network.mailboxes[id] = &memoryMailbox{notify: make(chan struct{}, 1)}
+	}
+	return network
+}
+
+// Transport returns the endpoint of party id.
+func (n *MemoryNetwork) Transport(id PartyID) Transport {
+	return &memoryTransport{network: n, id: id}
+}
+
+func (n *MemoryNetwork) mailbox(id PartyID) (*memoryMailbox, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	mailbox, ok := n.mailboxes[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownParty, "party %d", id)
+	}
+	return mailbox, nil
+}
+
+type memoryTransport struct {
+	network *MemoryNetwork
+	id      PartyID
+}
+
+func (t *memoryTransport) Send(ctx context.Context, msg *Message) error {
+	mailbox, err := t.network.mailbox(msg.To)
+	if err != nil {
+		return err
+	}
+	copied := *msg
+	copied.From = t.id
+
+	mailbox.mu.Lock()
+	mailbox.messages = append(mailbox.messages, &copied)
+	mailbox.mu.Unlock()
+
+	select {
+	case mailbox.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (t *memoryTransport) Receive(ctx context.Context) (*Message, error) {
+	mailbox, err := t.network.mailbox(t.id)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		mailbox.mu.Lock()
+		if len(mailbox.messages) > 0 {
+			msg := mailbox.messages[0]
+			mailbox.messages = mailbox.messages[1:]
+			mailbox.mu.Unlock()
+			return msg, nil
+		}
+		mailbox.mu.Unlock()
+
+		select {
+		case <-mailbox.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// roundAbort tells the other parties of a session to give up.
+const roundAbort = "abort"
+
+// inbox collects the messages of one session round by round. Messages of later
+// rounds that arrive early are kept until they are asked for.
+type inbox struct {
+	session string
+	receive func(ctx context.Context) (*Message, error)
+	pending []*Message
+}
+
+// collect returns the payload sent by each of from in round.
+func (in *inbox) collect(ctx context.Context, round string, from []PartyID) (map[PartyID]json.RawMessage, error) {
+	payloads := make(map[PartyID]json.RawMessage, len(from))
+	expected := make(map[PartyID]bool, len(from))
+	for _, id := range from {
+		expected[id] = true
+	}
+
+	accept := func(msg *Message) (bool, error) {
+		if msg.Round == roundAbort && expected[msg.From] {
+			var reason string
+			_ = json.Unmarshal(msg.Payload, &reason)
+			return true, errors.Wrapf(ErrAborted, "by party %d: %s", msg.From, reason)
+		}
+		if msg.Round != round || !expected[msg.From] {
+			return false, nil
+		}
+		if _, ok := payloads[msg.From]; ok {
+			return true, errors.Wrapf(ErrInvalidMessage, "duplicate %s message from party %d", round, msg.From)
+		}
+		payloads[msg.From] = msg.Payload
+		return true, nil
+	}
+
+	pending := in.pending[:0]
+	for _, msg := range in.pending {
+		used, err := accept(msg)
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			pending = append(pending, msg)
+		}
+	}
+	in.pending = pending
+
+	for len(payloads) < len(from) {
+		msg, err := in.receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if msg.Session != in.session {
+			continue
+		}
+		used, err := accept(msg)
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			in.pending = append(in.pending, msg)
+		}
+	}
+	return payloads, nil
+}
+
+func send(ctx context.Context, transport Transport, session, round string, to PartyID, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return transport.Send(ctx, &Message{Session: session, Round: round, To: to, Payload: data})
+}
+
+// abort notifies parties that this party gives up on session.
+func abort(transport Transport, session string, parties []PartyID, self PartyID, reason error) {
+	// best effort: the ceremony has already failed
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	for _, id := range parties {
+		if id != self {
+			_ = send(ctx, transport, session, roundAbort, id, reason.Error())
+		}
+	}
+}
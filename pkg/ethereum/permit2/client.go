@@ -0,0 +1,286 @@
+package permit2
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+// Address is the canonical Permit2 deployment, the same on every chain.
+var Address = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+
+var ErrLengthMismatch = errors.New("permitted tokens and transfer details have different lengths")
+
+type (
+	PermitDetails            = IAllowanceTransferPermitDetails
+	PermitSingle             = IAllowanceTransferPermitSingle
+	PermitBatch              = IAllowanceTransferPermitBatch
+	TokenPermissions         = ISignatureTransferTokenPermissions
+	PermitTransferFrom       = ISignatureTransferPermitTransferFrom
+	PermitBatchTransferFrom  = ISignatureTransferPermitBatchTransferFrom
+	SignatureTransferDetails = ISignatureTransferSignatureTransferDetails
+)
+
+var (
+	permitDetailsTypes = []eip712.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint160"},
+		{Name: "expiration", Type: "uint48"},
+		{Name: "nonce", Type: "uint48"},
+	}
+	tokenPermissionsTypes = []eip712.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+	}
+)
+
+// Allowance is the AllowanceTransfer allowance of a spender over the tokens of
+// an owner. Nonce is the nonce the next PermitSingle or PermitBatch must use.
+type Allowance struct {
+	Amount     *big.Int
+	Expiration *big.Int
+	Nonce      *big.Int
+}
+
+// Client reads Permit2 state, signs its EIP-712 messages and encodes its
+// calls. Tokens must have approved the Permit2 contract first.
+type Client struct {
+	address  common.Address
+	chainID  *big.Int
+	client   *ethclient.Client
+	contract *IPermit2
+	abi      *abi.ABI
+}
+
+func NewClient(address common.Address, chainID *big.Int, client *ethclient.Client) (*Client, error) {
+	contract, err := NewIPermit2(address, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := IPermit2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		address:  address,
+		chainID:  chainID,
+		client:   client,
+		contract: contract,
+		abi:      parsed,
+	}, nil
+}
+
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// Domain returns the EIP-712 domain of Permit2, which has no version.
+func (c *Client) Domain() eip712.Domain {
+	return eip712.Domain{
+		Name:              "Permit2",
+		ChainId:           (*math.HexOrDecimal256)(c.chainID),
+		VerifyingContract: c.address.Hex(),
+	}
+}
+
+func (c *Client) Allowance(ctx context.Context, owner common.Address, token common.Address, spender common.Address) (*Allowance, error) {
+	allowance, err := c.contract.Allowance(&bind.CallOpts{Context: ctx}, owner, token, spender)
+	if err != nil {
+		return nil, err
+	}
+	return &Allowance{
+		Amount:     allowance.Amount,
+		Expiration: allowance.Expiration,
+		Nonce:      allowance.Nonce,
+	}, nil
+}
+
+// PermitDetails returns the details of an allowance of spender for amount of
+// token until expiration, with the nonce read from the current allowance.
+func (c *Client) PermitDetails(ctx context.Context, owner common.Address, token common.Address, spender common.Address, amount *big.Int, expiration *big.Int) (PermitDetails, error) {
+	allowance, err := c.Allowance(ctx, owner, token, spender)
+	if err != nil {
+		return PermitDetails{}, err
+	}
+	return PermitDetails{
+		Token:      token,
+		Amount:     amount,
+		Expiration: expiration,
+		Nonce:      allowance.Nonce,
+	}, nil
+}
+
+func (c *Client) typedData(primaryType string, types eip712.Types, message eip712.Message) *eip712.TypedData {
+	domain := c.Domain()
+	types[eip712.DomainType] = eip712.DomainTypes(domain)
+	return &eip712.TypedData{
+		Types:       types,
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	}
+}
+
+func permitDetailsMessage(details PermitDetails) map[string]interface{} {
+	return map[string]interface{}{
+		"token":      details.Token.Hex(),
+		"amount":     details.Amount.String(),
+		"expiration": details.Expiration.String(),
+		"nonce":      details.Nonce.String(),
+	}
+}
+
+func tokenPermissionsMessage(permitted TokenPermissions) map[string]interface{} {
+	return map[string]interface{}{
+		"token":  permitted.Token.Hex(),
+		"amount": permitted.Amount.String(),
+	}
+}
+
+func (c *Client) PermitSingleTypedData(permit PermitSingle) *eip712.TypedData {
+	return c.typedData("PermitSingle", eip712.Types{
+		"PermitSingle": {
+			{Name: "details", Type: "PermitDetails"},
+			{Name: "spender", Type: "address"},
+			{Name: "sigDeadline", Type: "uint256"},
+		},
+		"PermitDetails": permitDetailsTypes,
+	}, eip712.Message{
+		"details":     permitDetailsMessage(permit.Details),
+		"spender":     permit.Spender.Hex(),
+		"sigDeadline": permit.SigDeadline.String(),
+	})
+}
+
+func (c *Client) PermitBatchTypedData(permit PermitBatch) *eip712.TypedData {
+	details := make([]interface{}, len(permit.Details))
+	for i := range permit.Details {
+		details[i] = permitDetailsMessage(permit.Details[i])
+	}
+	return c.typedData("PermitBatch", eip712.Types{
+		"PermitBatch": {
+			{Name: "details", Type: "PermitDetails[]"},
+			{Name: "spender", Type: "address"},
+			{Name: "sigDeadline", Type: "uint256"},
+		},
+		"PermitDetails": permitDetailsTypes,
+	}, eip712.Message{
+		"details":     details,
+		"spender":     permit.Spender.Hex(),
+		"sigDeadline": permit.SigDeadline.String(),
+	})
+}
+
+// PermitTransferFromTypedData returns the message signed for permit. spender
+// isn't part of the on-chain struct: Permit2 hashes msg.sender in its place,
+// so only spender can use the signature.
+func (c *Client) PermitTransferFromTypedData(permit PermitTransferFrom, spender common.Address) *eip712.TypedData {
+	return c.typedData("PermitTransferFrom", eip712.Types{
+		"PermitTransferFrom": {
+			{Name: "permitted", Type: "TokenPermissions"},
+			{Name: "spender", Type: "address"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+		"TokenPermissions": tokenPermissionsTypes,
+	}, eip712.Message{
+		"permitted": tokenPermissionsMessage(permit.Permitted),
+		"spender":   spender.Hex(),
+		"nonce":     permit.Nonce.String(),
+		"deadline":  permit.Deadline.String(),
+	})
+}
+
+func (c *Client) PermitBatchTransferFromTypedData(permit PermitBatchTransferFrom, spender common.Address) *eip712.TypedData {
+	permitted := make([]interface{}, len(permit.Permitted))
+	for i := range permit.Permitted {
+		permitted[i] = tokenPermissionsMessage(permit.Permitted[i])
+	}
+	return c.typedData("PermitBatchTransferFrom", eip712.Types{
+		"PermitBatchTransferFrom": {
+			{Name: "permitted", Type: "TokenPermissions[]"},
+			{Name: "spender", Type: "address"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+		"TokenPermissions": tokenPermissionsTypes,
+	}, eip712.Message{
+		"permitted": permitted,
+		"spender":   spender.Hex(),
+		"nonce":     permit.Nonce.String(),
+		"deadline":  permit.Deadline.String(),
+	})
+}
+
+func (c *Client) SignPermitSingle(signer ethereum.Signer, permit PermitSingle) ([]byte, error) {
+	return signer.SignTypedData(*c.PermitSingleTypedData(permit))
+}
+
+func (c *Client) SignPermitBatch(signer ethereum.Signer, permit PermitBatch) ([]byte, error) {
+	return signer.SignTypedData(*c.PermitBatchTypedData(permit))
+}
+
+func (c *Client) SignPermitTransferFrom(signer ethereum.Signer, permit PermitTransferFrom, spender common.Address) ([]byte, error) {
+	return signer.SignTypedData(*c.PermitTransferFromTypedData(permit, spender))
+}
+
+func (c *Client) SignPermitBatchTransferFrom(signer ethereum.Signer, permit PermitBatchTransferFrom, spender common.Address) ([]byte, error) {
+	return signer.SignTypedData(*c.PermitBatchTransferFromTypedData(permit, spender))
+}
+
+// PermitArgs returns the arguments of the permit call setting the allowance
+// signed by owner, sent by relayer.
+func (c *Client) PermitArgs(relayer common.Address, owner common.Address, permit PermitSingle, signature []byte) (_types.SendTxArgs, error) {
+	return c.callArgs(relayer, "permit", owner, permit, signature)
+}
+
+func (c *Client) PermitBatchArgs(relayer common.Address, owner common.Address, permit PermitBatch, signature []byte) (_types.SendTxArgs, error) {
+	// abigen names the second overload permit0
+	return c.callArgs(relayer, "permit0", owner, permit, signature)
+}
+
+// TransferFromArgs returns the arguments of a transfer by spender out of its
+// AllowanceTransfer allowance.
+func (c *Client) TransferFromArgs(spender common.Address, from common.Address, to common.Address, amount *big.Int, token common.Address) (_types.SendTxArgs, error) {
+	return c.callArgs(spender, "transferFrom", from, to, amount, token)
+}
+
+// ApproveArgs returns the arguments of an on-chain AllowanceTransfer approval.
+func (c *Client) ApproveArgs(owner common.Address, token common.Address, spender common.Address, amount *big.Int, expiration *big.Int) (_types.SendTxArgs, error) {
+	return c.callArgs(owner, "approve", token, spender, amount, expiration)
+}
+
+// PermitTransferFromArgs returns the arguments of a signature transfer, which
+// must be sent by the spender the permit was signed for.
+func (c *Client) PermitTransferFromArgs(spender common.Address, permit PermitTransferFrom, details SignatureTransferDetails, owner common.Address, signature []byte) (_types.SendTxArgs, error) {
+	return c.callArgs(spender, "permitTransferFrom", permit, details, owner, signature)
+}
+
+func (c *Client) PermitBatchTransferFromArgs(spender common.Address, permit PermitBatchTransferFrom, details []SignatureTransferDetails, owner common.Address, signature []byte) (_types.SendTxArgs, error) {
+	if len(permit.Permitted) != len(details) {
+		return _types.SendTxArgs{}, errors.Wrapf(ErrLengthMismatch, "%d permitted and %d transfers", len(permit.Permitted), len(details))
+	}
+	return c.callArgs(spender, "permitTransferFrom0", permit, details, owner, signature)
+}
+
+func (c *Client) callArgs(from common.Address, method string, args ...interface{}) (_types.SendTxArgs, error) {
+	data, err := c.abi.Pack(method, args...)
+	if err != nil {
+		return _types.SendTxArgs{}, err
+	}
+	to := c.address
+	return _types.SendTxArgs{
+		From:  from,
+		To:    &to,
+		Input: data,
+	}, nil
+}
@@ -0,0 +1,210 @@
+package permit2
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/openweb3-io/anychain/pkg/ethereum/internal/ethtest"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sigverify"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testToken   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	testToken2  = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	testSpender = common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD")
+)
+
+// fakePermit2API answers the Permit2 view calls from in-memory state.
+type fakePermit2API struct {
+	mu      sync.Mutex
+	abi     *abi.ABI
+	nonces  map[common.Address]uint64
+	bitmaps map[common.Address]map[uint64]*big.Int
+}
+
+func (api *fakePermit2API) setBit(owner common.Address, nonce uint64) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if api.bitmaps[owner] == nil {
+		api.bitmaps[owner] = make(map[uint64]*big.Int)
+	}
+	bitmap := api.bitmaps[owner][nonce>>8]
+	if bitmap == nil {
+		bitmap = new(big.Int)
+	}
+	api.bitmaps[owner][nonce>>8] = new(big.Int).SetBit(bitmap, int(nonce&0xff), 1)
+}
+
+func (api *fakePermit2API) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	input := hexutil.MustDecode(args["input"].(string))
+	method, err := api.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, err
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "allowance":
+		return method.Outputs.Pack(big.NewInt(1000), big.NewInt(1<<40), new(big.Int).SetUint64(api.nonces[values[0].(common.Address)]))
+	case "nonceBitmap":
+		bitmap := api.bitmaps[values[0].(common.Address)][values[1].(*big.Int).Uint64()]
+		if bitmap == nil {
+			bitmap = new(big.Int)
+		}
+		return method.Outputs.Pack(bitmap)
+	}
+	return nil, nil
+}
+
+func newTestClient(t *testing.T) (*Client, *fakePermit2API) {
+	parsed, err := IPermit2MetaData.GetAbi()
+	require.NoError(t, err)
+	api := &fakePermit2API{
+		abi:     parsed,
+		nonces:  make(map[common.Address]uint64),
+		bitmaps: make(map[common.Address]map[uint64]*big.Int),
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", api))
+	t.Cleanup(server.Stop)
+
+	client, err := NewClient(Address, big.NewInt(1), ethclient.NewClient(rpc.DialInProc(server)))
+	require.NoError(t, err)
+	return client, api
+}
+
+func newTestSigner(t *testing.T) *ethereum.PrivateKeySigner {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return ethereum.NewPrivateKeySigner(key)
+}
+
+func TestAllowanceTransfer(t *testing.T) {
+	ctx := context.Background()
+	client, api := newTestClient(t)
+	owner := ethtest.TypedDataSigner{PrivateKeySigner: newTestSigner(t)}
+	relayer := newTestSigner(t)
+	api.nonces[owner.Address()] = 7
+
+	details, err := client.PermitDetails(ctx, owner.Address(), testToken, testSpender, big.NewInt(5_000_000), big.NewInt(1<<40))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), details.Nonce.Int64())
+
+	// type hashes hardcoded in Permit2's PermitHash library
+	permitDetails := "PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"
+	single := PermitSingle{Details: details, Spender: testSpender, SigDeadline: big.NewInt(1 << 40)}
+	typedData := client.PermitSingleTypedData(single)
+	require.Equal(t, crypto.Keccak256Hash([]byte("PermitSingle(PermitDetails details,address spender,uint256 sigDeadline)"+permitDetails)), eip712.TypeHash(typedData, "PermitSingle"))
+	require.Equal(t, crypto.Keccak256Hash([]byte(permitDetails)), eip712.TypeHash(typedData, "PermitDetails"))
+
+	sig, err := client.SignPermitSingle(owner, single)
+	require.NoError(t, err)
+	signer, err := sigverify.RecoverTypedData(typedData, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner.Address(), signer)
+
+	args, err := client.PermitArgs(relayer.Address(), owner.Address(), single, sig)
+	require.NoError(t, err)
+	require.Equal(t, Address, *args.To)
+	require.Equal(t, relayer.Address(), args.From)
+	values := unpackCall(t, client, args.GetInput(), "permit")
+	require.Equal(t, owner.Address(), values[0])
+	require.Equal(t, sig, values[2])
+
+	batch := PermitBatch{Details: []PermitDetails{details, details}, Spender: testSpender, SigDeadline: big.NewInt(1 << 40)}
+	batch.Details[1].Token = testToken2
+	typedData = client.PermitBatchTypedData(batch)
+	require.Equal(t, crypto.Keccak256Hash([]byte("PermitBatch(PermitDetails[] details,address spender,uint256 sigDeadline)"+permitDetails)), eip712.TypeHash(typedData, "PermitBatch"))
+	sig, err = client.SignPermitBatch(owner, batch)
+	require.NoError(t, err)
+	signer, err = sigverify.RecoverTypedData(typedData, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner.Address(), signer)
+
+	args, err = client.PermitBatchArgs(relayer.Address(), owner.Address(), batch, sig)
+	require.NoError(t, err)
+	unpackCall(t, client, args.GetInput(), "permit0")
+
+	args, err = client.TransferFromArgs(testSpender, owner.Address(), relayer.Address(), big.NewInt(1), testToken)
+	require.NoError(t, err)
+	values = unpackCall(t, client, args.GetInput(), "transferFrom")
+	require.Equal(t, testToken, values[3])
+}
+
+func TestSignatureTransfer(t *testing.T) {
+	client, _ := newTestClient(t)
+	owner := ethtest.TypedDataSigner{PrivateKeySigner: newTestSigner(t)}
+	nonce, err := RandomNonce()
+	require.NoError(t, err)
+
+	tokenPermissions := "TokenPermissions(address token,uint256 amount)"
+	permit := PermitTransferFrom{
+		Permitted: TokenPermissions{Token: testToken, Amount: big.NewInt(1_000_000)},
+		Nonce:     nonce,
+		Deadline:  big.NewInt(1 << 40),
+	}
+	typedData := client.PermitTransferFromTypedData(permit, testSpender)
+	require.Equal(t, crypto.Keccak256Hash([]byte("PermitTransferFrom(TokenPermissions permitted,address spender,uint256 nonce,uint256 deadline)"+tokenPermissions)), eip712.TypeHash(typedData, "PermitTransferFrom"))
+
+	sig, err := client.SignPermitTransferFrom(owner, permit, testSpender)
+	require.NoError(t, err)
+	signer, err := sigverify.RecoverTypedData(typedData, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner.Address(), signer)
+
+	// the signature is bound to the spender
+	signer, err = sigverify.RecoverTypedData(client.PermitTransferFromTypedData(permit, owner.Address()), sig)
+	require.NoError(t, err)
+	require.NotEqual(t, owner.Address(), signer)
+
+	args, err := client.PermitTransferFromArgs(testSpender, permit, SignatureTransferDetails{To: testSpender, RequestedAmount: big.NewInt(500_000)}, owner.Address(), sig)
+	require.NoError(t, err)
+	values := unpackCall(t, client, args.GetInput(), "permitTransferFrom")
+	require.Equal(t, owner.Address(), values[2])
+
+	batch := PermitBatchTransferFrom{
+		Permitted: []TokenPermissions{permit.Permitted, {Token: testToken2, Amount: big.NewInt(2)}},
+		Nonce:     nonce,
+		Deadline:  big.NewInt(1 << 40),
+	}
+	typedData = client.PermitBatchTransferFromTypedData(batch, testSpender)
+	require.Equal(t, crypto.Keccak256Hash([]byte("PermitBatchTransferFrom(TokenPermissions[] permitted,address spender,uint256 nonce,uint256 deadline)"+tokenPermissions)), eip712.TypeHash(typedData, "PermitBatchTransferFrom"))
+	sig, err = client.SignPermitBatchTransferFrom(owner, batch, testSpender)
+	require.NoError(t, err)
+	signer, err = sigverify.RecoverTypedData(typedData, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner.Address(), signer)
+
+	details := []SignatureTransferDetails{{To: testSpender, RequestedAmount: big.NewInt(1)}, {To: testSpender, RequestedAmount: big.NewInt(2)}}
+	args, err = client.PermitBatchTransferFromArgs(testSpender, batch, details, owner.Address(), sig)
+	require.NoError(t, err)
+	unpackCall(t, client, args.GetInput(), "permitTransferFrom0")
+
+	_, err = client.PermitBatchTransferFromArgs(testSpender, batch, details[:1], owner.Address(), sig)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
+
+func unpackCall(t *testing.T, client *Client, input []byte, name string) []interface{} {
+	method, err := client.abi.MethodById(input[:4])
+	require.NoError(t, err)
+	require.Equal(t, name, method.Name)
+	values, err := method.Inputs.Unpack(input[4:])
+	require.NoError(t, err)
+	return values
+}
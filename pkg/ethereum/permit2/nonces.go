@@ -0,0 +1,207 @@
+package permit2
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+// SignatureTransfer nonces are unordered: nonce >> 8 selects a 256-bit word of
+// the owner's bitmap and the low 8 bits select the bit marking it used.
+const (
+	nonceBits = 8
+	// maxNonceWords bounds the words NonceManager scans for a free nonce.
+	maxNonceWords = 64
+)
+
+var (
+	ErrNoFreeNonce = errors.New("no free nonce")
+
+	maxNonce = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+)
+
+// NonceWordPos returns the bitmap word and the bit within it of nonce.
+func NonceWordPos(nonce *big.Int) (*big.Int, uint) {
+	bitPos := new(big.Int).And(nonce, big.NewInt(0xff))
+	return new(big.Int).Rsh(nonce, nonceBits), uint(bitPos.Uint64())
+}
+
+// NonceFromWordPos is the inverse of NonceWordPos.
+func NonceFromWordPos(wordPos *big.Int, bitPos uint) *big.Int {
+	nonce := new(big.Int).Lsh(wordPos, nonceBits)
+	return nonce.Or(nonce, big.NewInt(int64(bitPos&0xff)))
+}
+
+// RandomNonce returns a random SignatureTransfer nonce, for callers that don't
+// track the nonces they hand out.
+func RandomNonce() (*big.Int, error) {
+	return rand.Int(rand.Reader, maxNonce)
+}
+
+func (c *Client) NonceBitmap(ctx context.Context, owner common.Address, wordPos *big.Int) (*big.Int, error) {
+	return c.contract.NonceBitmap(&bind.CallOpts{Context: ctx}, owner, wordPos)
+}
+
+// IsNonceUsed reports whether the SignatureTransfer nonce of owner was used or
+// invalidated.
+func (c *Client) IsNonceUsed(ctx context.Context, owner common.Address, nonce *big.Int) (bool, error) {
+	wordPos, bitPos := NonceWordPos(nonce)
+	bitmap, err := c.NonceBitmap(ctx, owner, wordPos)
+	if err != nil {
+		return false, err
+	}
+	return bitmap.Bit(int(bitPos)) == 1, nil
+}
+
+// InvalidateUnorderedNoncesArgs returns the invalidateUnorderedNonces calls
+// that cancel nonces of owner, one per bitmap word.
+func (c *Client) InvalidateUnorderedNoncesArgs(owner common.Address, nonces ...*big.Int) ([]_types.SendTxArgs, error) {
+	masks := make(map[string]*big.Int)
+	words := make(map[string]*big.Int)
+	for _, nonce := range nonces {
+		wordPos, bitPos := NonceWordPos(nonce)
+		key := wordPos.String()
+		if _, ok := masks[key]; !ok {
+			masks[key] = new(big.Int)
+			words[key] = wordPos
+		}
+		masks[key].SetBit(masks[key], int(bitPos), 1)
+	}
+
+	keys := make([]string, 0, len(words))
+	for key := range words {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return words[keys[i]].Cmp(words[keys[j]]) < 0 })
+
+	args := make([]_types.SendTxArgs, 0, len(keys))
+	for _, key := range keys {
+		arg, err := c.callArgs(owner, "invalidateUnorderedNonces", words[key], masks[key])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// NonceManager hands out unused SignatureTransfer nonces. It reserves the
+// nonces it returns until they show up in the on-chain bitmap or the deadline
+// of their permit passes, so signatures made before earlier ones are submitted
+// don't collide. Reservations are kept in memory: owners shared between
+// processes should use RandomNonce or separate start words.
+type NonceManager struct {
+	client *Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	startWord *big.Int
+	cursors   map[common.Address]*big.Int
+	reserved  map[common.Address]map[string]reservation
+}
+
+type reservation struct {
+	nonce *big.Int
+	// deadline is the permit deadline in unix seconds
+	deadline *big.Int
+}
+
+func NewNonceManager(client *Client) *NonceManager {
+	return &NonceManager{
+		client:    client,
+		now:       time.Now,
+		startWord: new(big.Int),
+		cursors:   make(map[common.Address]*big.Int),
+		reserved:  make(map[common.Address]map[string]reservation),
+	}
+}
+
+// SetStartWord sets the first bitmap word scanned for owners not seen yet.
+func (m *NonceManager) SetStartWord(wordPos *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.startWord = new(big.Int).Set(wordPos)
+}
+
+// Next reserves and returns the lowest nonce of owner that is neither used
+// on-chain nor reserved, for a permit expiring at deadline. The bitmaps are
+// fetched without holding the lock, so owners don't wait on each other.
+func (m *NonceManager) Next(ctx context.Context, owner common.Address, deadline *big.Int) (*big.Int, error) {
+	m.mu.Lock()
+	wordPos, ok := m.cursors[owner]
+	if !ok {
+		wordPos = m.startWord
+	}
+	wordPos = new(big.Int).Set(wordPos)
+	m.mu.Unlock()
+
+	for i := 0; i < maxNonceWords; i++ {
+		bitmap, err := m.client.NonceBitmap(ctx, owner, wordPos)
+		if err != nil {
+			return nil, err
+		}
+		if nonce, ok := m.reserveFree(owner, wordPos, bitmap, deadline); ok {
+			return nonce, nil
+		}
+		wordPos = new(big.Int).Add(wordPos, common.Big1)
+	}
+	return nil, errors.Wrapf(ErrNoFreeNonce, "%s: %d words full", owner.Hex(), maxNonceWords)
+}
+
+// Release returns a nonce whose signature was discarded without being shared.
+func (m *NonceManager) Release(owner common.Address, nonce *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reserved[owner], nonce.String())
+}
+
+// reserveFree reserves the lowest bit of a word that is neither set in bitmap
+// nor reserved.
+func (m *NonceManager) reserveFree(owner common.Address, wordPos *big.Int, bitmap *big.Int, deadline *big.Int) (*big.Int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	taken := new(big.Int).Or(bitmap, m.reservedMask(owner, wordPos, bitmap))
+	for bit := 0; bit < 1<<nonceBits; bit++ {
+		if taken.Bit(bit) == 0 {
+			if cursor, ok := m.cursors[owner]; !ok || cursor.Cmp(wordPos) < 0 {
+				m.cursors[owner] = wordPos
+			}
+			nonce := NonceFromWordPos(wordPos, uint(bit))
+			if m.reserved[owner] == nil {
+				m.reserved[owner] = make(map[string]reservation)
+			}
+			m.reserved[owner][nonce.String()] = reservation{nonce: nonce, deadline: deadline}
+			return nonce, true
+		}
+	}
+	return nil, false
+}
+
+// reservedMask returns the reserved bits of a word, forgetting the
+// reservations already used according to bitmap and those whose permit
+// expired.
+func (m *NonceManager) reservedMask(owner common.Address, wordPos *big.Int, bitmap *big.Int) *big.Int {
+	now := big.NewInt(m.now().Unix())
+	mask := new(big.Int)
+	for key, r := range m.reserved[owner] {
+		word, bit := NonceWordPos(r.nonce)
+		if word.Cmp(wordPos) != 0 {
+			continue
+		}
+		if bitmap.Bit(int(bit)) == 1 || r.deadline.Cmp(now) < 0 {
+			delete(m.reserved[owner], key)
+			continue
+		}
+		mask.SetBit(mask, int(bit), 1)
+	}
+	return mask
+}
@@ -0,0 +1,109 @@
+package permit2
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceWordPos(t *testing.T) {
+	nonce, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	require.True(t, ok)
+	for _, nonce := range []*big.Int{big.NewInt(0), big.NewInt(255), big.NewInt(256), big.NewInt(70_000), nonce} {
+		wordPos, bitPos := NonceWordPos(nonce)
+		require.Equal(t, 0, NonceFromWordPos(wordPos, bitPos).Cmp(nonce), nonce.String())
+	}
+	wordPos, bitPos := NonceWordPos(big.NewInt(258))
+	require.Equal(t, int64(1), wordPos.Int64())
+	require.Equal(t, uint(2), bitPos)
+}
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	client, api := newTestClient(t)
+	owner := newTestSigner(t).Address()
+	api.setBit(owner, 0)
+	api.setBit(owner, 1)
+
+	used, err := client.IsNonceUsed(ctx, owner, big.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, used)
+	used, err = client.IsNonceUsed(ctx, owner, big.NewInt(2))
+	require.NoError(t, err)
+	require.False(t, used)
+
+	manager := NewNonceManager(client)
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+	deadline := big.NewInt(now.Add(time.Hour).Unix())
+	next := func() int64 {
+		nonce, err := manager.Next(ctx, owner, deadline)
+		require.NoError(t, err)
+		return nonce.Int64()
+	}
+	require.Equal(t, int64(2), next())
+	require.Equal(t, int64(3), next())
+
+	manager.Release(owner, big.NewInt(3))
+	require.Equal(t, int64(3), next())
+
+	// nonces used on-chain are forgotten and skipped
+	api.setBit(owner, 2)
+	api.setBit(owner, 4)
+	require.Equal(t, int64(5), next())
+	require.Len(t, manager.reserved[owner], 2)
+
+	// full words are skipped
+	for i := uint64(6); i < 256; i++ {
+		api.setBit(owner, i)
+	}
+	require.Equal(t, int64(256), next())
+
+	// reservations of expired permits are handed out again
+	require.Equal(t, int64(257), next())
+	now = now.Add(2 * time.Hour)
+	deadline = big.NewInt(now.Add(time.Hour).Unix())
+	require.Equal(t, int64(256), next())
+	require.Equal(t, int64(257), next())
+
+	args, err := client.InvalidateUnorderedNoncesArgs(owner, big.NewInt(1), big.NewInt(300), big.NewInt(3))
+	require.NoError(t, err)
+	require.Len(t, args, 2)
+	values := unpackCall(t, client, args[0].GetInput(), "invalidateUnorderedNonces")
+	require.Zero(t, values[0].(*big.Int).Sign())
+	require.Equal(t, big.NewInt(0b1010), values[1])
+	values = unpackCall(t, client, args[1].GetInput(), "invalidateUnorderedNonces")
+	require.Equal(t, big.NewInt(1), values[0])
+	require.Equal(t, new(big.Int).Lsh(big.NewInt(1), 44), values[1])
+}
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	owner := newTestSigner(t).Address()
+	manager := NewNonceManager(client)
+	deadline := big.NewInt(time.Now().Add(time.Hour).Unix())
+
+	nonces := make([]*big.Int, 300)
+	errs := make([]error, len(nonces))
+	var wg sync.WaitGroup
+	for i := range nonces {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonces[i], errs[i] = manager.Next(ctx, owner, deadline)
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i, nonce := range nonces {
+		require.NoError(t, errs[i])
+		require.False(t, seen[nonce.String()], nonce.String())
+		seen[nonce.String()] = true
+	}
+}
@@ -0,0 +1,37 @@
+package ethereum
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// DefaultReceiptPollInterval is how often the contract clients poll for
+// receipts unless told otherwise.
+const DefaultReceiptPollInterval = 2 * time.Second
+
+// WaitReceipt polls the receipt of hash every interval until the transaction
+// is mined or ctx is done.
+func WaitReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash, interval time.Duration) (*types.Receipt, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package remotesigner
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/pkg/errors"
+)
+
+var ErrUnknownAccount = errors.New("unknown account")
+
+// NewServer returns an in-process stand-in for a remote signer that serves both
+// the Clef and the Web3Signer methods for signers, without any approval step.
+// It is an http.Handler, so it can be served with net/http or httptest,
+// including over mTLS. It is meant for tests and local development only.
+func NewServer(signers ...ethereum.Signer) (*rpc.Server, error) {
+	backend := &standIn{signers: make(map[common.Address]ethereum.Signer, len(signers))}
+	for _, signer := range signers {
+		backend.signers[signer.Address()] = signer
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &clefService{backend}); err != nil {
+		return nil, err
+	}
+	if err := server.RegisterName("eth1", &web3SignerService{backend}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+type standIn struct {
+	signers map[common.Address]ethereum.Signer
+}
+
+func (b *standIn) signer(address common.Address) (ethereum.Signer, error) {
+	signer, ok := b.signers[address]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownAccount, address.Hex())
+	}
+	return signer, nil
+}
+
+type clefService struct {
+	backend *standIn
+}
+
+func (s *clefService) List(ctx context.Context) []common.Address {
+	addresses := make([]common.Address, 0, len(s.backend.signers))
+	for address := range s.backend.signers {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+func (s *clefService) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*signTransactionResult, error) {
+	signer, err := s.backend.signer(args.From.Address())
+	if err != nil {
+		return nil, err
+	}
+	if args.ChainID == nil {
+		return nil, errors.New("chainId is required")
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := signer.SignTx((*big.Int)(args.ChainID), tx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signTransactionResult{Raw: raw, Tx: signed}, nil
+}
+
+// SignData only supports text/plain, i.e. personal_sign, with hex encoded data.
+func (s *clefService) SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data string) (hexutil.Bytes, error) {
+	if contentType != "text/plain" {
+		return nil, errors.Wrapf(ErrUnsupported, "content type %q", contentType)
+	}
+	signer, err := s.backend.signer(addr.Address())
+	if err != nil {
+		return nil, err
+	}
+	message, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignText(message)
+}
+
+func (s *clefService) SignTypedData(ctx context.Context, addr common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	signer, err := s.backend.signer(addr.Address())
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignTypedData(typedData)
+}
+
+type web3SignerService struct {
+	backend *standIn
+}
+
+// Sign signs a digest and returns the signature with V as 27 or 28.
+func (s *web3SignerService) Sign(ctx context.Context, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	signer, err := s.backend.signer(address)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
@@ -0,0 +1,279 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/pkg/errors"
+)
+
+// Protocol selects the JSON-RPC dialect spoken by the remote signer.
+type Protocol string
+
+const (
+	// ProtocolClef uses account_signTransaction, account_signData and
+	// account_signTypedData. Clef refuses to sign raw digests, so Sign is
+	// not supported.
+	ProtocolClef Protocol = "clef"
+	// ProtocolWeb3Signer uses eth1_sign, which signs a raw digest; transactions
+	// and messages are hashed locally.
+	ProtocolWeb3Signer Protocol = "web3signer"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+var (
+	ErrUnsupported       = errors.New("operation not supported by the remote signer")
+	ErrUnknownProtocol   = errors.New("unknown remote signer protocol")
+	ErrRemoteSignature   = errors.New("remote signer returned an invalid signature")
+	ErrRemoteTransaction = errors.New("remote signer returned a different transaction")
+)
+
+type Config struct {
+	URL      string
+	Address  common.Address
+	Protocol Protocol
+	// Timeout bounds each signing request, DefaultTimeout if zero.
+	Timeout time.Duration
+	// TLS is used for https URLs, e.g. as built by LoadClientTLS for mTLS.
+	TLS *tls.Config
+}
+
+// LoadClientTLS builds a TLS config presenting the client certificate
+// certFile/keyFile and trusting the CAs of caFile. caFile may be empty to use
+// the system roots.
+func LoadClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load CA")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificate found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// Signer delegates signing of a single account to a remote signing service.
+// Every signature it returns is checked to recover to Address.
+type Signer struct {
+	client   *rpc.Client
+	address  common.Address
+	protocol Protocol
+	timeout  time.Duration
+}
+
+var _ ethereum.Signer = (*Signer)(nil)
+
+func NewSigner(ctx context.Context, cfg Config) (*Signer, error) {
+	if cfg.Protocol != ProtocolClef && cfg.Protocol != ProtocolWeb3Signer {
+		return nil, errors.Wrapf(ErrUnknownProtocol, "%q", cfg.Protocol)
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.TLS != nil {
+		transport.TLSClientConfig = cfg.TLS.Clone()
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: timeout}
+
+	client, err := rpc.DialOptions(ctx, cfg.URL, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		client:   client,
+		address:  cfg.Address,
+		protocol: cfg.Protocol,
+		timeout:  timeout,
+	}, nil
+}
+
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+func (s *Signer) Close() {
+	s.client.Close()
+}
+
+func (s *Signer) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.client.CallContext(ctx, result, method, args...); err != nil {
+		return errors.Wrapf(err, "remote signer %s", method)
+	}
+	return nil
+}
+
+// Sign signs a digest. V is 0 or 1.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	if s.protocol != ProtocolWeb3Signer {
+		return nil, errors.Wrap(ErrUnsupported, "sign digest")
+	}
+
+	var sig hexutil.Bytes
+	if err := s.call(&sig, "eth1_sign", s.address, hexutil.Bytes(payload)); err != nil {
+		return nil, err
+	}
+	sig, err := s.checkSignature(payload, sig)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	return sig, nil
+}
+
+func (s *Signer) SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	if s.protocol != ProtocolClef {
+		return ethereum.SignTxWithHash(s, chainID, tx)
+	}
+
+	args, err := toSendTxArgs(s.address, chainID, tx)
+	if err != nil {
+		return nil, err
+	}
+	var result signTransactionResult
+	if err := s.call(&result, "account_signTransaction", args, nil); err != nil {
+		return nil, err
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, errors.Wrap(ErrRemoteTransaction, err.Error())
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, ErrRemoteTransaction
+	}
+	sender, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return nil, errors.Wrap(ErrRemoteSignature, err.Error())
+	}
+	if sender != s.address {
+		return nil, errors.Wrapf(ErrRemoteSignature, "signed by %s", sender.Hex())
+	}
+	return signed, nil
+}
+
+func (s *Signer) SignText(message []byte) ([]byte, error) {
+	if s.protocol != ProtocolClef {
+		return ethereum.SignTextWithHash(s, message)
+	}
+
+	var sig hexutil.Bytes
+	if err := s.call(&sig, "account_signData", "text/plain", common.NewMixedcaseAddress(s.address), hexutil.Encode(message)); err != nil {
+		return nil, err
+	}
+	return s.checkSignature(accounts.TextHash(message), sig)
+}
+
+func (s *Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	if s.protocol != ProtocolClef {
+		return ethereum.SignTypedDataWithHash(s, typedData)
+	}
+
+	hash, err := eip712.Hash(&typedData)
+	if err != nil {
+		return nil, err
+	}
+	var sig hexutil.Bytes
+	if err := s.call(&sig, "account_signTypedData", common.NewMixedcaseAddress(s.address), typedData); err != nil {
+		return nil, err
+	}
+	return s.checkSignature(hash[:], sig)
+}
+
+// checkSignature verifies that sig recovers to the signer's address and returns
+// it with V as 27 or 28, whichever form the remote used.
+func (s *Signer) checkSignature(hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, errors.Wrapf(ErrRemoteSignature, "expected %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+	sig = bytes.Clone(sig)
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+
+	recoverable := bytes.Clone(sig)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(hash, recoverable)
+	if err != nil {
+		return nil, errors.Wrap(ErrRemoteSignature, err.Error())
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != s.address {
+		return nil, errors.Wrapf(ErrRemoteSignature, "signed by %s", signer.Hex())
+	}
+	return sig, nil
+}
+
+// signTransactionResult is the result of account_signTransaction.
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func toSendTxArgs(from common.Address, chainID *big.Int, tx *types.Transaction) (*apitypes.SendTxArgs, error) {
+	input := hexutil.Bytes(tx.Data())
+	args := &apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(from),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Input:   &input,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if to := tx.To(); to != nil {
+		mixed := common.NewMixedcaseAddress(*to)
+		args.To = &mixed
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+	default:
+		return nil, errors.Wrapf(ErrUnsupported, "transaction type %d", tx.Type())
+	}
+	return args, nil
+}
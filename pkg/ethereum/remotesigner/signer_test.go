@@ -0,0 +1,240 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/stretchr/testify/require"
+)
+
+const typedDataJSON = `{
+  "types": {
+    "EIP712Domain": [{"name": "name", "type": "string"}, {"name": "chainId", "type": "uint256"}],
+    "Greeting": [{"name": "text", "type": "string"}]
+  },
+  "primaryType": "Greeting",
+  "domain": {"name": "anychain", "chainId": 1},
+  "message": {"text": "hello"}
+}`
+
+type testPKI struct {
+	serverTLS *tls.Config
+	clientTLS *tls.Config
+}
+
+// newTestPKI issues a CA, a server certificate for 127.0.0.1 and a client
+// certificate, and returns matching mTLS configs.
+func newTestPKI(t *testing.T) *testPKI {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	issue := func(serial int64, usage x509.ExtKeyUsage) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "test"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+
+	return &testPKI{
+		serverTLS: &tls.Config{
+			Certificates: []tls.Certificate{issue(2, x509.ExtKeyUsageServerAuth)},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		},
+		clientTLS: &tls.Config{
+			Certificates: []tls.Certificate{issue(3, x509.ExtKeyUsageClientAuth)},
+			RootCAs:      pool,
+		},
+	}
+}
+
+func newTestServer(t *testing.T, handler http.Handler, pki *testPKI) *httptest.Server {
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = pki.serverTLS
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := ethereum.NewPrivateKeySigner(key)
+
+	rpcServer, err := NewServer(local)
+	require.NoError(t, err)
+	pki := newTestPKI(t)
+	server := newTestServer(t, rpcServer, pki)
+
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x1")
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(1e9), Value: big.NewInt(1)}),
+		types.NewTx(&types.AccessListTx{ChainID: chainID, Nonce: 2, To: &to, Gas: 21000, GasPrice: big.NewInt(1e9)}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, Gas: 60000, GasFeeCap: big.NewInt(2e9), GasTipCap: big.NewInt(1e9), Data: []byte{0x60, 0x00}}),
+	}
+	typedData, err := eip712.Parse([]byte(typedDataJSON))
+	require.NoError(t, err)
+
+	for _, protocol := range []Protocol{ProtocolClef, ProtocolWeb3Signer} {
+		t.Run(string(protocol), func(t *testing.T) {
+			signer, err := NewSigner(context.Background(), Config{
+				URL:      server.URL,
+				Address:  local.Address(),
+				Protocol: protocol,
+				TLS:      pki.clientTLS,
+			})
+			require.NoError(t, err)
+			defer signer.Close()
+
+			for _, tx := range txs {
+				signed, err := signer.SignTx(chainID, tx)
+				require.NoError(t, err)
+				sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+				require.NoError(t, err)
+				require.Equal(t, local.Address(), sender)
+			}
+
+			sig, err := signer.SignText([]byte("hello"))
+			require.NoError(t, err)
+			expected, err := local.SignText([]byte("hello"))
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+
+			sig, err = signer.SignTypedData(*typedData)
+			require.NoError(t, err)
+			expected, err = local.SignTypedData(*typedData)
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+
+			hash := crypto.Keccak256([]byte("digest"))
+			sig, err = signer.Sign(hash)
+			if protocol == ProtocolClef {
+				require.ErrorIs(t, err, ErrUnsupported)
+				return
+			}
+			require.NoError(t, err)
+			expected, err = local.Sign(hash)
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+		})
+	}
+}
+
+func TestSignerRejectsForeignSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := ethereum.NewPrivateKeySigner(key)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// the stand-in signs for local, but the server claims to be other's signer
+	rpcServer, err := NewServer(&impostor{PrivateKeySigner: local, address: crypto.PubkeyToAddress(other.PublicKey)})
+	require.NoError(t, err)
+	pki := newTestPKI(t)
+	server := newTestServer(t, rpcServer, pki)
+
+	signer, err := NewSigner(context.Background(), Config{
+		URL:      server.URL,
+		Address:  crypto.PubkeyToAddress(other.PublicKey),
+		Protocol: ProtocolWeb3Signer,
+		TLS:      pki.clientTLS,
+	})
+	require.NoError(t, err)
+	defer signer.Close()
+
+	_, err = signer.Sign(crypto.Keccak256([]byte("digest")))
+	require.ErrorIs(t, err, ErrRemoteSignature)
+}
+
+type impostor struct {
+	*ethereum.PrivateKeySigner
+	address common.Address
+}
+
+func (s *impostor) Address() common.Address {
+	return s.address
+}
+
+func TestSignerTLSAndTimeout(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	local := ethereum.NewPrivateKeySigner(key)
+	rpcServer, err := NewServer(local)
+	require.NoError(t, err)
+	pki := newTestPKI(t)
+
+	server := newTestServer(t, rpcServer, pki)
+	withoutClientCert := pki.clientTLS.Clone()
+	withoutClientCert.Certificates = nil
+	signer, err := NewSigner(context.Background(), Config{
+		URL:      server.URL,
+		Address:  local.Address(),
+		Protocol: ProtocolWeb3Signer,
+		TLS:      withoutClientCert,
+	})
+	require.NoError(t, err)
+	_, err = signer.Sign(crypto.Keccak256([]byte("digest")))
+	require.Error(t, err)
+	signer.Close()
+
+	slow := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		rpcServer.ServeHTTP(w, r)
+	}), pki)
+	signer, err = NewSigner(context.Background(), Config{
+		URL:      slow.URL,
+		Address:  local.Address(),
+		Protocol: ProtocolWeb3Signer,
+		Timeout:  50 * time.Millisecond,
+		TLS:      pki.clientTLS,
+	})
+	require.NoError(t, err)
+	defer signer.Close()
+	_, err = signer.Sign(crypto.Keccak256([]byte("digest")))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = NewSigner(context.Background(), Config{URL: slow.URL, Protocol: "ledger"})
+	require.ErrorIs(t, err, ErrUnknownProtocol)
+}
@@ -0,0 +1,104 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	compactSignatureSize = 64
+	// maxSignatureSize allows V to be encoded on up to 32 bytes, as produced by
+	// tools that append the EIP-155 V of chains with large ids.
+	maxSignatureSize = 96
+)
+
+var (
+	// Secp256k1N is the order of the secp256k1 curve. Don't modify it.
+	Secp256k1N = crypto.S256().Params().N
+	// Secp256k1HalfN is the largest s of a low-s signature. Don't modify it.
+	Secp256k1HalfN = new(big.Int).Rsh(Secp256k1N, 1)
+)
+
+// NormalizeSignature converts an externally produced signature into go-ethereum's
+// 65-byte [R || S || V] format with V in {0, 1} and S in the lower half of the
+// curve order. It accepts:
+//   - V as 0/1, as 27/28, or as an EIP-155 value 35 + 2*chainID + {0, 1},
+//     possibly spread over several bytes
+//   - 64-byte EIP-2098 compact signatures
+//   - high-s signatures, which are flipped to low-s
+//
+// chainID is only used to check EIP-155 V values and may be nil.
+func NormalizeSignature(sig []byte, chainID *big.Int) ([]byte, error) {
+	if len(sig) < compactSignatureSize || len(sig) > maxSignatureSize {
+		return nil, errors.Wrapf(_types.ErrInvalidSignatureSize, "got %d bytes", len(sig))
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+
+	var recoveryID uint64
+	if len(sig) == compactSignatureSize {
+		// EIP-2098: the top bit of s holds the y parity
+		recoveryID = uint64(s.Bit(255))
+		s.SetBit(s, 255, 0)
+	} else {
+		var err error
+		recoveryID, err = recoveryIDFromV(new(big.Int).SetBytes(sig[64:]), chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Sign() <= 0 || r.Cmp(Secp256k1N) >= 0 || s.Sign() <= 0 || s.Cmp(Secp256k1N) >= 0 {
+		return nil, errors.Wrap(_types.ErrInvalidSignature, "r or s out of range")
+	}
+	// (r, n-s) is the same signature for the negated nonce point
+	if s.Cmp(Secp256k1HalfN) > 0 {
+		s.Sub(Secp256k1N, s)
+		recoveryID ^= 1
+	}
+
+	normalized := make([]byte, ValidSignatureSize)
+	r.FillBytes(normalized[:32])
+	s.FillBytes(normalized[32:64])
+	normalized[crypto.RecoveryIDOffset] = byte(recoveryID)
+	return normalized, nil
+}
+
+func recoveryIDFromV(v *big.Int, chainID *big.Int) (uint64, error) {
+	if !v.IsUint64() {
+		return 0, errors.Wrapf(_types.ErrInvalidSignature, "invalid v %s", v)
+	}
+
+	switch v := v.Uint64(); {
+	case v == 0 || v == 1:
+		return v, nil
+	case v == 27 || v == 28:
+		return v - 27, nil
+	case v >= 35:
+		id := (v - 35) / 2
+		if chainID != nil && (!chainID.IsUint64() || chainID.Uint64() != id) {
+			return 0, errors.Wrapf(_types.ErrInvalidSignature, "v %d is for chain %d, expected %s", v, id, chainID)
+		}
+		return (v - 35) % 2, nil
+	default:
+		return 0, errors.Wrapf(_types.ErrInvalidSignature, "invalid v %d", v)
+	}
+}
+
+// CheckSender returns an *ErrSenderMismatch if signedTx wasn't signed by from.
+func CheckSender(chainID *big.Int, signedTx *types.Transaction, from common.Address) error {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return errors.Wrap(_types.ErrInvalidSignature, err.Error())
+	}
+	if sender != from {
+		return &_types.ErrSenderMismatch{Expected: from, Recovered: sender}
+	}
+	return nil
+}
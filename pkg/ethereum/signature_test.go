@@ -0,0 +1,125 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+// signatureFormats returns sig, a 0/1 V signature, in the formats produced by
+// external tools.
+func signatureFormats(sig []byte, chainID *big.Int) map[string][]byte {
+	r, s, v := sig[:32], sig[32:64], sig[64]
+
+	legacy := append(append([]byte{}, r...), s...)
+	legacy = append(legacy, v+27)
+
+	eip155V := new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35+int64(v)))
+	eip155 := append(append([]byte{}, r...), s...)
+	eip155 = append(eip155, eip155V.Bytes()...)
+
+	compact := append(append([]byte{}, r...), s...)
+	compact[32] |= v << 7
+
+	highS := new(big.Int).Sub(Secp256k1N, new(big.Int).SetBytes(s))
+	high := append(append([]byte{}, r...), common.LeftPadBytes(highS.Bytes(), 32)...)
+	high = append(high, v^1)
+
+	return map[string][]byte{
+		"canonical": sig,
+		"v27":       legacy,
+		"eip155":    eip155,
+		"eip2098":   compact,
+		"highS":     high,
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	for _, chainID := range []*big.Int{big.NewInt(1), big.NewInt(137), big.NewInt(11155111)} {
+		for i := 0; i < 8; i++ {
+			hash := crypto.Keccak256(big.NewInt(int64(i)).Bytes())
+			sig, err := crypto.Sign(hash, key)
+			require.NoError(t, err)
+
+			for name, external := range signatureFormats(sig, chainID) {
+				normalized, err := NormalizeSignature(external, chainID)
+				require.NoError(t, err, name)
+				require.Equal(t, sig, normalized, name)
+			}
+		}
+	}
+
+	sig, err := crypto.Sign(crypto.Keccak256([]byte("hello")), key)
+	require.NoError(t, err)
+
+	_, err = NormalizeSignature(signatureFormats(sig, big.NewInt(1))["eip155"], big.NewInt(56))
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+
+	_, err = NormalizeSignature(sig[:63], nil)
+	require.ErrorIs(t, err, _types.ErrInvalidSignatureSize)
+
+	invalidV := append([]byte{}, sig...)
+	invalidV[64] = 30
+	_, err = NormalizeSignature(invalidV, nil)
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+
+	zeroR := append(make([]byte, 32), sig[32:]...)
+	_, err = NormalizeSignature(zeroR, nil)
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+}
+
+func TestBuildTransactionWithSignature(t *testing.T) {
+	api := newFakeEthAPI(1)
+	transactor := NewTransactor(newFakeClient(api), big.NewInt(1), nil)
+	chainID := big.NewInt(1)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	gas := hexutil.Uint64(21000)
+	nonce := hexutil.Uint64(0)
+	args := _types.SendTxArgs{
+		From:     from,
+		To:       &to,
+		Gas:      &gas,
+		GasPrice: (*hexutil.Big)(big.NewInt(1_000_000_000)),
+		Value:    (*hexutil.Big)(big.NewInt(1)),
+		Nonce:    &nonce,
+	}
+
+	hash := types.LatestSignerForChainID(chainID).Hash(transactor.buildTransaction(args))
+	sig, err := crypto.Sign(hash[:], key)
+	require.NoError(t, err)
+
+	for name, external := range signatureFormats(sig, chainID) {
+		tx, err := transactor.BuildTransactionWithSignature(context.Background(), chainID, args, external)
+		require.NoError(t, err, name)
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+		require.NoError(t, err)
+		require.Equal(t, from, sender, name)
+	}
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sig, err = crypto.Sign(hash[:], other)
+	require.NoError(t, err)
+
+	_, err = transactor.BuildTransactionWithSignature(context.Background(), chainID, args, sig)
+	var mismatch *_types.ErrSenderMismatch
+	require.True(t, errors.As(err, &mismatch))
+	require.Equal(t, from, mismatch.Expected)
+	require.Equal(t, crypto.PubkeyToAddress(other.PublicKey), mismatch.Recovered)
+	require.ErrorIs(t, err, _types.ErrInvalidTxSender)
+}
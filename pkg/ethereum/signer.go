@@ -2,24 +2,98 @@ package ethereum
 
 import (
 	"crypto/ecdsa"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
 )
 
-type Signer interface {
+// HashSigner signs 32-byte digests on behalf of a single account and returns
+// signatures in the [R || S || V] format with V in {0, 1}.
+type HashSigner interface {
+	Address() common.Address
 	Sign(payload []byte) ([]byte, error)
 }
 
+// Signer is a HashSigner that also knows how to hash what it signs.
+type Signer interface {
+	HashSigner
+	// SignTx signs any transaction type with the latest signer of chainID.
+	SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+	// SignText signs an EIP-191 personal_sign message. V is 27 or 28.
+	SignText(message []byte) ([]byte, error)
+	// SignTypedData signs EIP-712 typed data. V is 27 or 28.
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// SignTxWithHash implements Signer.SignTx on top of a HashSigner.
+func SignTxWithHash(s HashSigner, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	txSigner := types.LatestSignerForChainID(chainID)
+	sig, err := s.Sign(txSigner.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, sig)
+}
+
+// SignTextWithHash implements Signer.SignText on top of a HashSigner.
+func SignTextWithHash(s HashSigner, message []byte) ([]byte, error) {
+	return signWithLegacyV(s, accounts.TextHash(message))
+}
+
+// SignTypedDataWithHash implements Signer.SignTypedData on top of a HashSigner.
+// typedData is validated and hashed by eip712.Hash.
+func SignTypedDataWithHash(s HashSigner, typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := eip712.Hash(&typedData)
+	if err != nil {
+		return nil, err
+	}
+	return signWithLegacyV(s, hash[:])
+}
+
+// signWithLegacyV shifts V to 27/28 as expected by ecrecover and wallets for
+// off-chain messages.
+func signWithLegacyV(s HashSigner, hash []byte) ([]byte, error) {
+	sig, err := s.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
 type PrivateKeySigner struct {
 	privateKey *ecdsa.PrivateKey
+	address    common.Address
 }
 
 func NewPrivateKeySigner(privateKey *ecdsa.PrivateKey) *PrivateKeySigner {
 	return &PrivateKeySigner{
-		privateKey,
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
 	}
 }
 
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
 func (s *PrivateKeySigner) Sign(payload []byte) ([]byte, error) {
 	return crypto.Sign(payload, s.privateKey)
 }
+
+func (s *PrivateKeySigner) SignTx(chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	return SignTxWithHash(s, chainID, tx)
+}
+
+func (s *PrivateKeySigner) SignText(message []byte) ([]byte, error) {
+	return SignTextWithHash(s, message)
+}
+
+func (s *PrivateKeySigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return SignTypedDataWithHash(s, typedData)
+}
@@ -0,0 +1,79 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateKeySignerSignTx(t *testing.T) {
+	key, _ := gethcrypto.GenerateKey()
+	signer := NewPrivateKeySigner(key)
+	require.Equal(t, gethcrypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	chainID := big.NewInt(11155111)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	txs := []types.TxData{
+		&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to},
+		&types.AccessListTx{ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to},
+		&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, To: &to},
+	}
+
+	for _, txData := range txs {
+		signed, err := signer.SignTx(chainID, types.NewTx(txData))
+		require.NoError(t, err)
+		require.Equal(t, chainID, signed.ChainId())
+
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, signer.Address(), sender)
+	}
+}
+
+func TestPrivateKeySignerSignMessages(t *testing.T) {
+	key, _ := gethcrypto.GenerateKey()
+	signer := NewPrivateKeySigner(key)
+
+	recoverAddress := func(hash []byte, sig []byte) common.Address {
+		require.Contains(t, []byte{27, 28}, sig[64])
+		sig = append([]byte(nil), sig...)
+		sig[64] -= 27
+		pub, err := gethcrypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		return gethcrypto.PubkeyToAddress(*pub)
+	}
+
+	message := []byte("hello")
+	sig, err := signer.SignText(message)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), recoverAddress(accounts.TextHash(message), sig))
+
+	// fixed-size arrays are hashed by eip712.Hash only
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Greeting":     {{Name: "text", Type: "string"}, {Name: "pair", Type: "uint256[2]"}},
+		},
+		PrimaryType: "Greeting",
+		Domain:      apitypes.TypedDataDomain{Name: "test", ChainId: math.NewHexOrDecimal256(1)},
+		Message:     apitypes.TypedDataMessage{"text": "hello", "pair": []interface{}{"1", "2"}},
+	}
+	sig, err = signer.SignTypedData(typedData)
+	require.NoError(t, err)
+
+	hash, err := eip712.Hash(&typedData)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), recoverAddress(hash[:], sig))
+
+	typedData.Message["pair"] = []interface{}{"1"}
+	_, err = signer.SignTypedData(typedData)
+	require.ErrorIs(t, err, eip712.ErrInvalidTypedData)
+}
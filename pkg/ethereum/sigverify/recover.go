@@ -0,0 +1,44 @@
+package sigverify
+
+import (
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+// RecoverHash returns the EOA that signed hash. Any signature format accepted by
+// ethereum.NormalizeSignature is supported.
+func RecoverHash(hash common.Hash, sig []byte) (common.Address, error) {
+	sig, err := ethereum.NormalizeSignature(sig, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return common.Address{}, errors.Wrap(_types.ErrInvalidSignature, err.Error())
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// RecoverText returns the EOA that signed an EIP-191 personal_sign message.
+func RecoverText(message []byte, sig []byte) (common.Address, error) {
+	return RecoverHash(TextHash(message), sig)
+}
+
+// RecoverTypedData returns the EOA that signed EIP-712 typed data.
+func RecoverTypedData(typedData *eip712.TypedData, sig []byte) (common.Address, error) {
+	hash, err := eip712.Hash(typedData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return RecoverHash(hash, sig)
+}
+
+// TextHash returns the EIP-191 digest of a personal_sign message.
+func TextHash(message []byte) common.Hash {
+	return common.BytesToHash(accounts.TextHash(message))
+}
@@ -0,0 +1,134 @@
+package sigverify
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// validatorSource is the init code of a deployless ERC-6492 validator, the
+// isValidSigImpl of the spec's UniversalSigValidator. It's run by an eth_call
+// without a recipient, with validatorArgs appended, and returns a single byte:
+// 0x01 if the wallet accepts the signature, 0x00 otherwise.
+//
+// A wallet without code is deployed through its factory first. A deployed
+// wallet that rejects the signature gets the factory call as a prepare step
+// and is asked again, as wallets may need it to, say, rotate their owners.
+//
+// validatorCode is the source assembled with go-ethereum's core/asm.
+const validatorSource = `
+	;; copy the arguments appended to the code to memory 0:
+	;; signer, factory, offset of the factory calldata, offset of the check
+	PUSH @args
+	PUSH 1
+	ADD
+	DUP1
+	CODESIZE
+	SUB
+	SWAP1
+	PUSH 0
+	CODECOPY
+
+	PUSH 0
+	MLOAD
+	EXTCODESIZE
+	JUMPI @deployed
+
+	PUSH @deployed_counterfactual
+	JUMP @prepare
+deployed_counterfactual:
+	ISZERO
+	JUMPI @reject
+	PUSH @result
+	JUMP @check
+
+deployed:
+	PUSH @checked
+	JUMP @check
+checked:
+	JUMPI @accept
+	PUSH @prepared
+	JUMP @prepare
+prepared:
+	ISZERO
+	JUMPI @reject
+	PUSH @result
+	JUMP @check
+
+reject:
+	PUSH 0
+result:
+	PUSH 0
+	MSTORE8
+	PUSH 1
+	PUSH 0
+	RETURN
+accept:
+	PUSH 1
+	JUMP @result
+
+	;; [ret] -> [success]: calls the factory with its calldata
+prepare:
+	PUSH 0
+	PUSH 0
+	PUSH 0x40
+	MLOAD
+	DUP1
+	MLOAD
+	SWAP1
+	PUSH 0x20
+	ADD
+	PUSH 0
+	PUSH 0x20
+	MLOAD
+	GAS
+	CALL
+	SWAP1
+	JUMP
+
+	;; [ret] -> [valid]: calls isValidSignature on the signer and compares
+	;; the result, copied past the arguments, with the magic value
+check:
+	PUSH 0x20
+	CODESIZE
+	PUSH 0x60
+	MLOAD
+	DUP1
+	MLOAD
+	SWAP1
+	PUSH 0x20
+	ADD
+	PUSH 0
+	PUSH 0
+	MLOAD
+	GAS
+	CALL
+	PUSH 0x20
+	RETURNDATASIZE
+	LT
+	ISZERO
+	AND
+	CODESIZE
+	MLOAD
+	PUSH 224
+	SHR
+	PUSH 0x1626ba7e
+	EQ
+	AND
+	SWAP1
+	JUMP
+
+	;; the arguments start right after this last byte
+args:
+`
+
+var (
+	validatorCode = common.FromHex("63000000bc600101803803906000396000513b6300000037576300000024630000007d565b15630000006857630000006b6300000094565b63000000436300000094565b6300000074576300000055630000007d565b15630000006857630000006b6300000094565b60005b60005360016000f35b6001630000006b565b6000600060405180519060200160006020515af190565b60203860605180519060200160006000515af160203d101516385160e01c631626ba7e141690565b")
+	// validatorArgs is abi.encode(signer, factory, factoryCalldata,
+	// isValidSignatureCalldata).
+	validatorArgs = abi.Arguments{
+		{Type: mustNewType("address")},
+		{Type: mustNewType("address")},
+		{Type: mustNewType("bytes")},
+		{Type: mustNewType("bytes")},
+	}
+)
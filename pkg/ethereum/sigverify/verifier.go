@@ -0,0 +1,171 @@
+package sigverify
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	"github.com/pkg/errors"
+)
+
+var (
+	// MagicValue is returned by EIP-1271 isValidSignature for valid signatures.
+	MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+	// ERC6492Suffix ends the signatures of counterfactual wallets.
+	ERC6492Suffix = common.Hex2Bytes("6492649264926492649264926492649264926492649264926492649264926492")
+)
+
+var ErrValidator = errors.New("signature validator failed")
+
+const erc1271ABI = `[{"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"magicValue","type":"bytes4"}],"stateMutability":"view","type":"function"}]`
+
+var (
+	erc1271 = mustParseABI(erc1271ABI)
+	// erc6492Wrapper is abi.encode(factory, factoryCalldata, signature).
+	erc6492Wrapper = abi.Arguments{
+		{Type: mustNewType("address")},
+		{Type: mustNewType("bytes")},
+		{Type: mustNewType("bytes")},
+	}
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// Verifier checks signatures of EOAs, of deployed smart-contract wallets with
+// EIP-1271 and of wallets that need deploying or preparing with ERC-6492.
+type Verifier struct {
+	client *ethclient.Client
+}
+
+func NewVerifier(client *ethclient.Client) *Verifier {
+	return &Verifier{client: client}
+}
+
+// VerifyHash reports whether sig is a valid signature of hash by signer.
+// Invalid signatures return false with a nil error; errors are reserved for
+// failures to query the node.
+func (v *Verifier) VerifyHash(ctx context.Context, signer common.Address, hash common.Hash, sig []byte) (bool, error) {
+	if factory, factoryCalldata, inner, ok := unwrapERC6492(sig); ok {
+		return v.validateERC6492(ctx, signer, factory, factoryCalldata, hash, inner)
+	}
+
+	code, err := v.client.CodeAt(ctx, signer, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) > 0 {
+		return v.isValidSignature(ctx, signer, hash, sig)
+	}
+
+	recovered, err := RecoverHash(hash, sig)
+	if err != nil {
+		return false, nil
+	}
+	return recovered == signer, nil
+}
+
+// VerifyText reports whether sig is a valid EIP-191 personal_sign signature of
+// message by signer.
+func (v *Verifier) VerifyText(ctx context.Context, signer common.Address, message []byte, sig []byte) (bool, error) {
+	return v.VerifyHash(ctx, signer, TextHash(message), sig)
+}
+
+// VerifyTypedData reports whether sig is a valid EIP-712 signature of typedData
+// by signer.
+func (v *Verifier) VerifyTypedData(ctx context.Context, signer common.Address, typedData *eip712.TypedData, sig []byte) (bool, error) {
+	hash, err := eip712.Hash(typedData)
+	if err != nil {
+		return false, err
+	}
+	return v.VerifyHash(ctx, signer, hash, sig)
+}
+
+// isValidSignature calls EIP-1271 isValidSignature on a deployed wallet. A
+// revert means the wallet rejects the signature.
+func (v *Verifier) isValidSignature(ctx context.Context, wallet common.Address, hash common.Hash, sig []byte) (bool, error) {
+	data, err := erc1271.Pack("isValidSignature", hash, sig)
+	if err != nil {
+		return false, err
+	}
+	result, err := v.client.CallContract(ctx, goethereum.CallMsg{To: &wallet, Data: data}, nil)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isMagicValue(result), nil
+}
+
+// validateERC6492 runs the deployless validator with an eth_call, so the
+// wallet is deployed or prepared only in the call's throwaway state.
+func (v *Verifier) validateERC6492(ctx context.Context, wallet common.Address, factory common.Address, factoryCalldata []byte, hash common.Hash, sig []byte) (bool, error) {
+	check, err := erc1271.Pack("isValidSignature", hash, sig)
+	if err != nil {
+		return false, err
+	}
+	args, err := validatorArgs.Pack(wallet, factory, factoryCalldata, check)
+	if err != nil {
+		return false, err
+	}
+	data := append(append([]byte{}, validatorCode...), args...)
+	result, err := v.client.CallContract(ctx, goethereum.CallMsg{Data: data}, nil)
+	if err != nil {
+		if ethereum.IsRevert(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(result) != 1 {
+		return false, errors.Wrapf(ErrValidator, "unexpected result %x", result)
+	}
+	return result[0] == 1, nil
+}
+
+// unwrapERC6492 splits a signature ending with ERC6492Suffix into the factory,
+// its calldata and the wrapped signature.
+func unwrapERC6492(sig []byte) (common.Address, []byte, []byte, bool) {
+	if len(sig) < len(ERC6492Suffix) || !bytes.Equal(sig[len(sig)-len(ERC6492Suffix):], ERC6492Suffix) {
+		return common.Address{}, nil, nil, false
+	}
+	values, err := erc6492Wrapper.Unpack(sig[:len(sig)-len(ERC6492Suffix)])
+	if err != nil || len(values) != 3 {
+		return common.Address{}, nil, nil, false
+	}
+	return values[0].(common.Address), values[1].([]byte), values[2].([]byte), true
+}
+
+// WrapERC6492 wraps the signature of a counterfactual wallet with the factory
+// call that deploys it.
+func WrapERC6492(factory common.Address, factoryCalldata []byte, sig []byte) ([]byte, error) {
+	packed, err := erc6492Wrapper.Pack(factory, factoryCalldata, sig)
+	if err != nil {
+		return nil, err
+	}
+	return append(packed, ERC6492Suffix...), nil
+}
+
+func isMagicValue(result []byte) bool {
+	// bytes4 is returned left aligned in a 32-byte word
+	return len(result) >= 4 && bytes.Equal(result[:4], MagicValue[:])
+}
@@ -0,0 +1,299 @@
+package sigverify
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/eip712"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	deployedWallet = common.HexToAddress("0x00000000000000000000000000000000000A11e7")
+	staleWallet    = common.HexToAddress("0x0000000000000000000000000000000000057A1e")
+	factory        = common.HexToAddress("0x000000000000000000000000000000000000FAC7")
+)
+
+// walletSource is the runtime code of a smart-contract wallet owned by the EOA
+// in slot 0: isValidSignature accepts signatures of the owner and reverts
+// otherwise, and a call with just a word makes it the new owner.
+const walletSource = `
+	CALLDATASIZE
+	PUSH 0x20
+	EQ
+	JUMPI @set_owner
+
+	;; ecrecover(hash, v, r, s) of the 65-byte signature
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	DUP1
+	CALLDATALOAD
+	PUSH 65
+	EQ
+	ISZERO
+	JUMPI @reject
+	DUP1
+	PUSH 0x20
+	ADD
+	CALLDATALOAD
+	PUSH 0x40
+	MSTORE
+	DUP1
+	PUSH 0x40
+	ADD
+	CALLDATALOAD
+	PUSH 0x60
+	MSTORE
+	PUSH 0x60
+	ADD
+	CALLDATALOAD
+	PUSH 248
+	SHR
+	DUP1
+	PUSH 27
+	GT
+	PUSH 27
+	MUL
+	ADD
+	PUSH 0x20
+	MSTORE
+	PUSH 0x20
+	PUSH 0x80
+	PUSH 0x80
+	PUSH 0
+	PUSH 1
+	GAS
+	STATICCALL
+	POP
+	PUSH 0x80
+	MLOAD
+	PUSH 0
+	SLOAD
+	EQ
+	ISZERO
+	JUMPI @reject
+	PUSH 0x1626ba7e
+	PUSH 224
+	SHL
+	PUSH 0
+	MSTORE
+	PUSH 0x20
+	PUSH 0
+	RETURN
+reject:
+	PUSH 0
+	PUSH 0
+	REVERT
+set_owner:
+	PUSH 0
+	CALLDATALOAD
+	PUSH 0
+	SSTORE
+	STOP
+`
+
+// walletInitSource is the init code of a wallet: it stores the owner and
+// returns the runtime code appended after it.
+const walletInitSource = `
+	PUSH %s
+	PUSH 0
+	SSTORE
+	PUSH %d
+	DUP1
+	PUSH @runtime
+	PUSH 1
+	ADD
+	PUSH 0
+	CODECOPY
+	PUSH 0
+	RETURN
+runtime:
+`
+
+// factorySource deploys the init code in its calldata with CREATE2 and salt 0.
+const factorySource = `
+	CALLDATASIZE
+	PUSH 0
+	PUSH 0
+	CALLDATACOPY
+	PUSH 0
+	CALLDATASIZE
+	PUSH 0
+	PUSH 0
+	CREATE2
+	ISZERO
+	JUMPI @failed
+	STOP
+failed:
+	PUSH 0
+	PUSH 0
+	REVERT
+`
+
+func mustAssemble(source string) []byte {
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex([]byte(source), false))
+	code, errs := compiler.Compile()
+	if len(errs) > 0 {
+		panic(errs[0])
+	}
+	return common.FromHex(code)
+}
+
+func walletInitCode(owner common.Address) []byte {
+	runtime := mustAssemble(walletSource)
+	return append(mustAssemble(fmt.Sprintf(walletInitSource, owner.Hex(), len(runtime))), runtime...)
+}
+
+// fakeEthAPI runs calls, including deployless ones, in an EVM on top of state,
+// and drops their changes like eth_call.
+type fakeEthAPI struct {
+	state *state.StateDB
+}
+
+func (api *fakeEthAPI) GetCode(address common.Address, block string) hexutil.Bytes {
+	return api.state.GetCode(address)
+}
+
+func (api *fakeEthAPI) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+	if input == "" {
+		input, _ = args["data"].(string)
+	}
+	cfg := &runtime.Config{State: api.state.Copy(), GasLimit: 30_000_000}
+	if to, ok := args["to"].(string); ok {
+		result, _, err := runtime.Call(common.HexToAddress(to), hexutil.MustDecode(input), cfg)
+		return result, err
+	}
+	result, _, _, err := runtime.Create(hexutil.MustDecode(input), cfg)
+	return result, err
+}
+
+// newTestVerifier deploys a wallet of owner, a wallet of staleOwner and the
+// factory.
+func newTestVerifier(t *testing.T, owner common.Address, staleOwner common.Address) *Verifier {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	require.NoError(t, err)
+	for wallet, walletOwner := range map[common.Address]common.Address{deployedWallet: owner, staleWallet: staleOwner} {
+		statedb.SetCode(wallet, mustAssemble(walletSource))
+		statedb.SetState(wallet, common.Hash{}, common.BytesToHash(walletOwner[:]))
+	}
+	statedb.SetCode(factory, mustAssemble(factorySource))
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &fakeEthAPI{state: statedb}))
+	t.Cleanup(server.Stop)
+	return NewVerifier(ethclient.NewClient(rpc.DialInProc(server)))
+}
+
+func TestValidatorCode(t *testing.T) {
+	require.Equal(t, mustAssemble(validatorSource), validatorCode)
+}
+
+func TestRecover(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := ethereum.NewPrivateKeySigner(key)
+
+	sig, err := signer.SignText([]byte("hello"))
+	require.NoError(t, err)
+	recovered, err := RecoverText([]byte("hello"), sig)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), recovered)
+
+	typedData := &eip712.TypedData{
+		Types: eip712.Types{
+			eip712.DomainType: {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Mail":            {{Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain:      eip712.Domain{Name: "test", ChainId: (*math.HexOrDecimal256)(big.NewInt(1))},
+		Message:     eip712.Message{"contents": "hello"},
+	}
+	sig, err = signer.SignTypedData(*typedData)
+	require.NoError(t, err)
+	recovered, err = RecoverTypedData(typedData, sig)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), recovered)
+
+	_, err = RecoverHash(common.Hash{1}, make([]byte, 65))
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(ownerKey)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other := ethereum.NewPrivateKeySigner(otherKey)
+
+	verifier := newTestVerifier(t, owner.Address(), other.Address())
+	message := []byte("sign in")
+	ownerSig, err := owner.SignText(message)
+	require.NoError(t, err)
+	otherSig, err := other.SignText(message)
+	require.NoError(t, err)
+
+	initCode := walletInitCode(owner.Address())
+	undeployed := crypto.CreateAddress2(factory, common.Hash{}, crypto.Keccak256(initCode))
+	wrapped, err := WrapERC6492(factory, initCode, ownerSig)
+	require.NoError(t, err)
+	wrappedOther, err := WrapERC6492(factory, initCode, otherSig)
+	require.NoError(t, err)
+	wrappedBadFactory, err := WrapERC6492(factory, []byte{0x01}, ownerSig)
+	require.NoError(t, err)
+	// the prepare call of a deployed wallet hands it over to owner
+	wrappedPrepare, err := WrapERC6492(staleWallet, common.BytesToHash(owner.Address().Bytes()).Bytes(), ownerSig)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		signer common.Address
+		sig    []byte
+		valid  bool
+	}{
+		{"eoa", owner.Address(), ownerSig, true},
+		{"eoa mismatch", other.Address(), ownerSig, false},
+		{"eoa garbage", owner.Address(), []byte{1, 2, 3}, false},
+		{"eip1271", deployedWallet, ownerSig, true},
+		{"eip1271 rejected", deployedWallet, otherSig, false},
+		{"erc6492 deployed", deployedWallet, wrapped, true},
+		{"erc6492 counterfactual", undeployed, wrapped, true},
+		{"erc6492 counterfactual rejected", undeployed, wrappedOther, false},
+		{"erc6492 factory fails", undeployed, wrappedBadFactory, false},
+		{"erc6492 prepared", staleWallet, wrappedPrepare, true},
+		// prepare calls run in the eth_call only
+		{"eip1271 unprepared", staleWallet, ownerSig, false},
+		// without the wrapper an undeployed wallet is treated as an EOA
+		{"counterfactual unwrapped", undeployed, ownerSig, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := verifier.VerifyText(ctx, tc.signer, message, tc.sig)
+			require.NoError(t, err)
+			require.Equal(t, tc.valid, valid)
+		})
+	}
+}
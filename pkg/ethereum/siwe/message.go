@@ -0,0 +1,299 @@
+package siwe
+
+import (
+	"crypto/rand"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	Version = "1"
+
+	headerSuffix    = " wants you to sign in with your Ethereum account:"
+	uriTag          = "URI: "
+	versionTag      = "Version: "
+	chainIDTag      = "Chain ID: "
+	nonceTag        = "Nonce: "
+	issuedAtTag     = "Issued At: "
+	expirationTag   = "Expiration Time: "
+	notBeforeTag    = "Not Before: "
+	requestIDTag    = "Request ID: "
+	resourcesTag    = "Resources:"
+	resourcePrefix  = "- "
+	nonceLength     = 17
+	minNonceLength  = 8
+	nonceCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var ErrInvalidMessage = errors.New("invalid SIWE message")
+
+var (
+	nonceRegexp  = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+	schemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+\-.]*$`)
+)
+
+// Message is an EIP-4361 Sign-In with Ethereum message.
+type Message struct {
+	// Scheme is the optional URI scheme of the origin, e.g. "https".
+	Scheme    string
+	Domain    string
+	Address   common.Address
+	Statement string
+	URI       string
+	Version   string
+	ChainID   uint64
+	Nonce     string
+	IssuedAt  time.Time
+
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// GenerateNonce returns a random alphanumeric nonce.
+func GenerateNonce() (string, error) {
+	nonce := make([]byte, nonceLength)
+	max := big.NewInt(int64(len(nonceCharacters)))
+	for i := range nonce {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		nonce[i] = nonceCharacters[n.Int64()]
+	}
+	return string(nonce), nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return errors.Wrapf(ErrInvalidMessage, format, args...)
+}
+
+// Validate checks the fields against the EIP-4361 grammar.
+func (m *Message) Validate() error {
+	if m.Scheme != "" && !schemeRegexp.MatchString(m.Scheme) {
+		return invalid("invalid scheme %q", m.Scheme)
+	}
+	if m.Domain == "" || strings.ContainsAny(m.Domain, " \n/") {
+		return invalid("invalid domain %q", m.Domain)
+	}
+	if m.Address == (common.Address{}) {
+		return invalid("address is required")
+	}
+	if strings.Contains(m.Statement, "\n") {
+		return invalid("statement can't contain line breaks")
+	}
+	if err := validateURI(m.URI); err != nil {
+		return invalid("uri: %s", err)
+	}
+	if m.Version != Version {
+		return invalid("unsupported version %q", m.Version)
+	}
+	if m.ChainID == 0 {
+		return invalid("chain id is required")
+	}
+	if !nonceRegexp.MatchString(m.Nonce) {
+		return invalid("nonce must be at least %d alphanumeric characters", minNonceLength)
+	}
+	if m.IssuedAt.IsZero() {
+		return invalid("issued at is required")
+	}
+	if strings.Contains(m.RequestID, "\n") {
+		return invalid("request id can't contain line breaks")
+	}
+	for _, resource := range m.Resources {
+		if err := validateURI(resource); err != nil {
+			return invalid("resource %q: %s", resource, err)
+		}
+	}
+	return nil
+}
+
+func validateURI(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || strings.ContainsAny(s, " \n") {
+		return errors.Errorf("%q is not an absolute URI", s)
+	}
+	return nil
+}
+
+// String renders the message in the EIP-4361 format that gets signed with
+// personal_sign.
+func (m *Message) String() string {
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.Hex() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(uriTag + m.URI + "\n")
+	b.WriteString(versionTag + m.Version + "\n")
+	b.WriteString(chainIDTag + strconv.FormatUint(m.ChainID, 10) + "\n")
+	b.WriteString(nonceTag + m.Nonce + "\n")
+	b.WriteString(issuedAtTag + formatTime(m.IssuedAt))
+	if m.ExpirationTime != nil {
+		b.WriteString("\n" + expirationTag + formatTime(*m.ExpirationTime))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\n" + notBeforeTag + formatTime(*m.NotBefore))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\n" + requestIDTag + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\n" + resourcesTag)
+		for _, resource := range m.Resources {
+			b.WriteString("\n" + resourcePrefix + resource)
+		}
+	}
+	return b.String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ParseMessage parses and validates an EIP-4361 message.
+func ParseMessage(s string) (*Message, error) {
+	lines := strings.Split(s, "\n")
+	p := &parser{lines: lines}
+	m := new(Message)
+
+	header, ok := strings.CutSuffix(p.next(), headerSuffix)
+	if !ok {
+		return nil, invalid("line 1: missing header")
+	}
+	if scheme, domain, ok := strings.Cut(header, "://"); ok {
+		m.Scheme, m.Domain = scheme, domain
+	} else {
+		m.Domain = header
+	}
+
+	address := p.next()
+	if !common.IsHexAddress(address) || !strings.HasPrefix(address, "0x") {
+		return nil, invalid("line 2: invalid address %q", address)
+	}
+	m.Address = common.HexToAddress(address)
+	// EIP-4361 requires the EIP-55 checksum
+	if m.Address.Hex() != address {
+		return nil, invalid("line 2: address %q is not checksummed", address)
+	}
+
+	if p.next() != "" {
+		return nil, invalid("line 3: expected an empty line")
+	}
+	if statement := p.next(); statement != "" {
+		m.Statement = statement
+		if p.next() != "" {
+			return nil, invalid("line %d: expected an empty line", p.pos)
+		}
+	}
+
+	var err error
+	if m.URI, err = p.field(uriTag); err != nil {
+		return nil, err
+	}
+	if m.Version, err = p.field(versionTag); err != nil {
+		return nil, err
+	}
+	chainID, err := p.field(chainIDTag)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = strconv.ParseUint(chainID, 10, 64); err != nil {
+		return nil, invalid("line %d: invalid chain id %q", p.pos, chainID)
+	}
+	if m.Nonce, err = p.field(nonceTag); err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = p.timeField(issuedAtTag); err != nil {
+		return nil, err
+	}
+
+	if p.has(expirationTag) {
+		t, err := p.timeField(expirationTag)
+		if err != nil {
+			return nil, err
+		}
+		m.ExpirationTime = &t
+	}
+	if p.has(notBeforeTag) {
+		t, err := p.timeField(notBeforeTag)
+		if err != nil {
+			return nil, err
+		}
+		m.NotBefore = &t
+	}
+	if p.has(requestIDTag) {
+		m.RequestID, _ = p.field(requestIDTag)
+	}
+	if p.has(resourcesTag) && p.peek() == resourcesTag {
+		p.next()
+		for p.has(resourcePrefix) {
+			m.Resources = append(m.Resources, strings.TrimPrefix(p.next(), resourcePrefix))
+		}
+	}
+
+	if p.pos < len(lines) {
+		return nil, invalid("line %d: unexpected %q", p.pos+1, lines[p.pos])
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.lines) {
+		return ""
+	}
+	return p.lines[p.pos]
+}
+
+func (p *parser) next() string {
+	line := p.peek()
+	p.pos++
+	return line
+}
+
+func (p *parser) has(tag string) bool {
+	return p.pos < len(p.lines) && strings.HasPrefix(p.lines[p.pos], tag)
+}
+
+func (p *parser) field(tag string) (string, error) {
+	if !p.has(tag) {
+		return "", invalid("line %d: expected %q", p.pos+1, strings.TrimSpace(tag))
+	}
+	return strings.TrimPrefix(p.next(), tag), nil
+}
+
+func (p *parser) timeField(tag string) (time.Time, error) {
+	value, err := p.field(tag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, invalid("line %d: %s", p.pos, err)
+	}
+	return t, nil
+}
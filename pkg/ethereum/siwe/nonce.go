@@ -0,0 +1,80 @@
+package siwe
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownNonce = errors.New("unknown or expired nonce")
+	ErrNonceUsed    = errors.New("nonce already used")
+)
+
+// NonceStore keeps the nonces handed out to clients so every sign-in message
+// can only be used once. Implementations backed by a shared database let
+// several servers verify sign-ins.
+type NonceStore interface {
+	// Issue records a nonce that is valid until expiresAt.
+	Issue(ctx context.Context, nonce string, expiresAt time.Time) error
+	// Consume marks nonce as used. It returns ErrUnknownNonce if the nonce
+	// was never issued or has expired, and ErrNonceUsed if it was consumed.
+	Consume(ctx context.Context, nonce string) error
+}
+
+type memoryNonce struct {
+	expiresAt time.Time
+	used      bool
+}
+
+// MemoryNonceStore is a NonceStore for a single process. Expired nonces are
+// pruned when new ones are issued.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]*memoryNonce
+	now    func() time.Time
+}
+
+var _ NonceStore = (*MemoryNonceStore)(nil)
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]*memoryNonce),
+		now:    time.Now,
+	}
+}
+
+func (s *MemoryNonceStore) Issue(ctx context.Context, nonce string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for n, entry := range s.nonces {
+		if !now.Before(entry.expiresAt) {
+			delete(s.nonces, n)
+		}
+	}
+	if _, ok := s.nonces[nonce]; ok {
+		return errors.Wrap(ErrNonceUsed, "nonce issued twice")
+	}
+	s.nonces[nonce] = &memoryNonce{expiresAt: expiresAt}
+	return nil
+}
+
+func (s *MemoryNonceStore) Consume(ctx context.Context, nonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.nonces[nonce]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return ErrUnknownNonce
+	}
+	if entry.used {
+		return ErrNonceUsed
+	}
+	// keep used nonces until they expire to reject replays
+	entry.used = true
+	return nil
+}
@@ -0,0 +1,226 @@
+package siwe
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/openweb3-io/anychain/pkg/ethereum"
+	"github.com/openweb3-io/anychain/pkg/ethereum/sigverify"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/stretchr/testify/require"
+)
+
+// specMessage is the example of EIP-4361.
+const specMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParseMessage(t *testing.T) {
+	m, err := ParseMessage(specMessage)
+	require.NoError(t, err)
+	require.Equal(t, "service.invalid", m.Domain)
+	require.Equal(t, common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), m.Address)
+	require.Equal(t, "I accept the ServiceOrg Terms of Service: https://service.invalid/tos", m.Statement)
+	require.Equal(t, uint64(1), m.ChainID)
+	require.Equal(t, "32891756", m.Nonce)
+	require.Equal(t, time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC), m.IssuedAt)
+	require.Len(t, m.Resources, 2)
+	require.Equal(t, specMessage, m.String())
+
+	expiration := m.IssuedAt.Add(time.Hour)
+	full := &Message{
+		Scheme:         "https",
+		Domain:         "example.com:8443",
+		Address:        m.Address,
+		URI:            "https://example.com/login",
+		Version:        Version,
+		ChainID:        10,
+		Nonce:          "abcdefgh1234",
+		IssuedAt:       m.IssuedAt,
+		ExpirationTime: &expiration,
+		NotBefore:      &m.IssuedAt,
+		RequestID:      "req-1",
+	}
+	parsed, err := ParseMessage(full.String())
+	require.NoError(t, err)
+	require.Equal(t, full.String(), parsed.String())
+	require.Equal(t, "https", parsed.Scheme)
+	require.Empty(t, parsed.Statement)
+
+	testCases := []struct {
+		name     string
+		old, new string
+	}{
+		{"header", "wants you to sign in", "wants you to log in"},
+		{"lowercase address", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"},
+		{"version", "Version: 1", "Version: 2"},
+		{"chain id", "Chain ID: 1", "Chain ID: one"},
+		{"short nonce", "Nonce: 32891756", "Nonce: 1234"},
+		{"issued at", "2021-09-30T16:25:24Z", "yesterday"},
+		{"relative uri", "URI: https://service.invalid/login", "URI: /login"},
+		{"field order", "Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1"},
+		{"trailing line", "my-web2-claim.json", "my-web2-claim.json\nfoo"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseMessage(strings.Replace(specMessage, tc.old, tc.new, 1))
+			require.ErrorIs(t, err, ErrInvalidMessage)
+		})
+	}
+}
+
+func newTestMessage(t *testing.T, address common.Address, nonce string) *Message {
+	issuedAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	expiration := issuedAt.Add(time.Hour)
+	m := &Message{
+		Domain:         "example.com",
+		Address:        address,
+		Statement:      "Sign in to Example",
+		URI:            "https://example.com/login",
+		Version:        Version,
+		ChainID:        1,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiration,
+	}
+	require.NoError(t, m.Validate())
+	return m
+}
+
+func TestVerifyEOA(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := ethereum.NewPrivateKeySigner(key)
+
+	verifier := NewVerifier(nil, NewMemoryNonceStore())
+	nonce, err := verifier.NewNonce(ctx)
+	require.NoError(t, err)
+	message := newTestMessage(t, signer.Address(), nonce).String()
+	sig, err := signer.SignText([]byte(message))
+	require.NoError(t, err)
+
+	opts := VerifyOptions{Domain: "example.com", ChainID: 1}
+	_, err = verifier.Verify(ctx, message, sig, VerifyOptions{Domain: "evil.com"})
+	require.ErrorIs(t, err, ErrDomainMismatch)
+	_, err = verifier.Verify(ctx, message, sig, VerifyOptions{ChainID: 1})
+	require.ErrorIs(t, err, ErrDomainRequired)
+	_, err = verifier.Verify(ctx, message, sig, VerifyOptions{Domain: "example.com", ChainID: 5})
+	require.ErrorIs(t, err, ErrChainMismatch)
+	_, err = verifier.Verify(ctx, message, sig, VerifyOptions{Domain: "example.com", Time: time.Now().Add(2 * time.Hour)})
+	require.ErrorIs(t, err, ErrExpired)
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	badSig, err := ethereum.NewPrivateKeySigner(other).SignText([]byte(message))
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, message, badSig, opts)
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+
+	// failed attempts don't burn the nonce, a success does
+	m, err := verifier.Verify(ctx, message, sig, opts)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), m.Address)
+	_, err = verifier.Verify(ctx, message, sig, opts)
+	require.ErrorIs(t, err, ErrNonceUsed)
+
+	// nonces must have been issued
+	message = newTestMessage(t, signer.Address(), "unissued1234").String()
+	sig, err = signer.SignText([]byte(message))
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, message, sig, opts)
+	require.ErrorIs(t, err, ErrUnknownNonce)
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryNonceStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Issue(ctx, "nonce0001", now.Add(time.Minute)))
+	require.ErrorIs(t, store.Issue(ctx, "nonce0001", now.Add(time.Minute)), ErrNonceUsed)
+
+	now = now.Add(2 * time.Minute)
+	require.ErrorIs(t, store.Consume(ctx, "nonce0001"), ErrUnknownNonce)
+	require.NoError(t, store.Issue(ctx, "nonce0002", now.Add(time.Minute)))
+	require.NotContains(t, store.nonces, "nonce0001")
+}
+
+var contractWallet = common.HexToAddress("0x00000000000000000000000000000000000A11e7")
+
+// fakeEthAPI runs a contract wallet that accepts any signature of its owner.
+type fakeEthAPI struct {
+	owner common.Address
+}
+
+func (api *fakeEthAPI) GetCode(address common.Address, block string) hexutil.Bytes {
+	if address == contractWallet {
+		return hexutil.Bytes{0x60, 0x80}
+	}
+	return nil
+}
+
+func (api *fakeEthAPI) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input := hexutil.MustDecode(args["input"].(string))
+	// isValidSignature(bytes32 hash, bytes signature)
+	hash := common.BytesToHash(input[4:36])
+	size := new(big.Int).SetBytes(input[68:100]).Int64()
+	recovered, err := sigverify.RecoverHash(hash, input[100:100+size])
+	if err != nil || recovered != api.owner {
+		return nil, errors.New("execution reverted")
+	}
+	return common.RightPadBytes(sigverify.MagicValue[:], 32), nil
+}
+
+func TestVerifyContractWallet(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := ethereum.NewPrivateKeySigner(key)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &fakeEthAPI{owner: owner.Address()}))
+	defer server.Stop()
+	client := ethclient.NewClient(rpc.DialInProc(server))
+
+	verifier := NewVerifier(sigverify.NewVerifier(client), NewMemoryNonceStore())
+	nonce, err := verifier.NewNonce(ctx)
+	require.NoError(t, err)
+	message := newTestMessage(t, contractWallet, nonce).String()
+	sig, err := owner.SignText([]byte(message))
+	require.NoError(t, err)
+
+	m, err := verifier.Verify(ctx, message, sig, VerifyOptions{Domain: "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, contractWallet, m.Address)
+
+	nonce, err = verifier.NewNonce(ctx)
+	require.NoError(t, err)
+	message = newTestMessage(t, contractWallet, nonce).String()
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sig, err = ethereum.NewPrivateKeySigner(other).SignText([]byte(message))
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, message, sig, VerifyOptions{Domain: "example.com"})
+	require.ErrorIs(t, err, _types.ErrInvalidSignature)
+}
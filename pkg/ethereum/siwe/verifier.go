@@ -0,0 +1,134 @@
+package siwe
+
+import (
+	"context"
+	"time"
+
+	"github.com/openweb3-io/anychain/pkg/ethereum/sigverify"
+	_types "github.com/openweb3-io/anychain/pkg/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+const DefaultNonceTTL = 10 * time.Minute
+
+var (
+	ErrDomainRequired = errors.New("expected domain is required")
+	ErrDomainMismatch = errors.New("domain mismatch")
+	ErrChainMismatch  = errors.New("chain id mismatch")
+	ErrURIMismatch    = errors.New("uri mismatch")
+	ErrExpired        = errors.New("message expired")
+	ErrNotYetValid    = errors.New("message not yet valid")
+)
+
+// VerifyOptions are the expectations of the relying party. Domain is required
+// so that messages signed for another site are never accepted. The other zero
+// values are not checked, except Time which defaults to now.
+type VerifyOptions struct {
+	Domain  string
+	Scheme  string
+	ChainID uint64
+	URI     string
+	Time    time.Time
+}
+
+// Verifier checks signed sign-in messages and consumes their nonce.
+type Verifier struct {
+	signatures *sigverify.Verifier
+	nonces     NonceStore
+	nonceTTL   time.Duration
+}
+
+// NewVerifier returns a verifier checking signatures with signatures, which
+// handles EOAs and falls back to EIP-1271 for contract wallets. signatures may
+// be nil to only accept EOA signatures without a node.
+func NewVerifier(signatures *sigverify.Verifier, nonces NonceStore) *Verifier {
+	return &Verifier{
+		signatures: signatures,
+		nonces:     nonces,
+		nonceTTL:   DefaultNonceTTL,
+	}
+}
+
+// SetNonceTTL sets how long nonces returned by NewNonce stay valid.
+func (v *Verifier) SetNonceTTL(ttl time.Duration) {
+	v.nonceTTL = ttl
+}
+
+// NewNonce generates and records a nonce for a client about to sign in.
+func (v *Verifier) NewNonce(ctx context.Context) (string, error) {
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return "", err
+	}
+	if err := v.nonces.Issue(ctx, nonce, time.Now().Add(v.nonceTTL)); err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
+
+// Verify parses message, checks it against opts and its signature, then
+// consumes its nonce. The nonce is only consumed once everything else checks
+// out, so invalid attempts can't burn the nonce of a legitimate client.
+func (v *Verifier) Verify(ctx context.Context, message string, sig []byte, opts VerifyOptions) (*Message, error) {
+	if opts.Domain == "" {
+		return nil, ErrDomainRequired
+	}
+	m, err := ParseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Domain != opts.Domain {
+		return nil, errors.Wrapf(ErrDomainMismatch, "expected %s, got %s", opts.Domain, m.Domain)
+	}
+	if opts.Scheme != "" && m.Scheme != "" && m.Scheme != opts.Scheme {
+		return nil, errors.Wrapf(ErrDomainMismatch, "expected scheme %s, got %s", opts.Scheme, m.Scheme)
+	}
+	if opts.ChainID != 0 && m.ChainID != opts.ChainID {
+		return nil, errors.Wrapf(ErrChainMismatch, "expected %d, got %d", opts.ChainID, m.ChainID)
+	}
+	if opts.URI != "" && m.URI != opts.URI {
+		return nil, errors.Wrapf(ErrURIMismatch, "expected %s, got %s", opts.URI, m.URI)
+	}
+
+	now := opts.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return nil, ErrExpired
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return nil, ErrNotYetValid
+	}
+
+	if err := v.verifySignature(ctx, m, message, sig); err != nil {
+		return nil, err
+	}
+	if err := v.nonces.Consume(ctx, m.Nonce); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (v *Verifier) verifySignature(ctx context.Context, m *Message, message string, sig []byte) error {
+	if v.signatures == nil {
+		recovered, err := sigverify.RecoverText([]byte(message), sig)
+		if err != nil {
+			return err
+		}
+		if recovered != m.Address {
+			return errors.Wrapf(_types.ErrInvalidSignature, "signed by %s", recovered.Hex())
+		}
+		return nil
+	}
+
+	valid, err := v.signatures.VerifyText(ctx, m.Address, []byte(message), sig)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return _types.ErrInvalidSignature
+	}
+	return nil
+}
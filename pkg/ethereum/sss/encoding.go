@@ -0,0 +1,133 @@
+package sss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	shareVersion     = 1
+	shareChecksumLen = 4
+	wordBits         = 11
+)
+
+// Bytes serializes the share as version || threshold || index || value ||
+// checksum, the checksum catching transcription errors of a single share.
+func (s Share) Bytes() []byte {
+	data := make([]byte, 0, 3+len(s.Value)+shareChecksumLen)
+	data = append(data, shareVersion, s.Threshold, s.Index)
+	data = append(data, s.Value...)
+	sum := sha256.Sum256(data)
+	return append(data, sum[:shareChecksumLen]...)
+}
+
+// Hex encodes the share as a hex string.
+func (s Share) Hex() string {
+	return hex.EncodeToString(s.Bytes())
+}
+
+// Words encodes the share with the BIP-39 English word list, 11 bits per word.
+// The first word holds the byte length of the share, so that the zero padding
+// of the last word can't be mistaken for data; it fits the shares of secrets up
+// to MaxSecretSize.
+func (s Share) Words() string {
+	data := s.Bytes()
+	wordList := bip39.GetWordList()
+
+	words := []string{wordList[len(data)]}
+	var acc, bits uint
+	for _, b := range data {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= wordBits {
+			bits -= wordBits
+			words = append(words, wordList[acc>>bits&(1<<wordBits-1)])
+		}
+	}
+	if bits > 0 {
+		words = append(words, wordList[acc<<(wordBits-bits)&(1<<wordBits-1)])
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseShare decodes a share encoded by Hex or Words.
+func ParseShare(s string) (Share, error) {
+	s = strings.TrimSpace(s)
+	var data []byte
+	if strings.ContainsAny(s, " \t\n") {
+		var err error
+		if data, err = decodeWords(s); err != nil {
+			return Share{}, err
+		}
+	} else {
+		var err error
+		if data, err = hex.DecodeString(strings.TrimPrefix(s, "0x")); err != nil {
+			return Share{}, errors.Wrap(ErrInvalidShare, err.Error())
+		}
+	}
+	return ParseShareBytes(data)
+}
+
+// ParseShareBytes decodes a share serialized by Bytes.
+func ParseShareBytes(data []byte) (Share, error) {
+	if len(data) < 3+1+shareChecksumLen {
+		return Share{}, errors.Wrap(ErrInvalidShare, "too short")
+	}
+	body, checksum := data[:len(data)-shareChecksumLen], data[len(data)-shareChecksumLen:]
+	sum := sha256.Sum256(body)
+	if !bytes.Equal(sum[:shareChecksumLen], checksum) {
+		return Share{}, errors.Wrap(ErrInvalidShare, "bad checksum")
+	}
+	if body[0] != shareVersion {
+		return Share{}, errors.Wrapf(ErrUnsupportedShare, "version %d", body[0])
+	}
+	if body[1] < 2 || body[2] == 0 {
+		return Share{}, errors.Wrap(ErrInvalidShare, "bad threshold or index")
+	}
+	return Share{
+		Threshold: body[1],
+		Index:     body[2],
+		Value:     append([]byte(nil), body[3:]...),
+	}, nil
+}
+
+func decodeWords(s string) ([]byte, error) {
+	indexes := make([]int, 0, len(s)/4)
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		index, ok := bip39.GetWordIndex(word)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidShare, "unknown word %q", word)
+		}
+		indexes = append(indexes, index)
+	}
+
+	if len(indexes) < 2 {
+		return nil, errors.Wrap(ErrInvalidShare, "too short")
+	}
+	length := indexes[0]
+	if len(indexes)-1 != (length*8+wordBits-1)/wordBits {
+		return nil, errors.Wrapf(ErrInvalidShare, "%d words for %d bytes", len(indexes)-1, length)
+	}
+
+	data := make([]byte, 0, length)
+	var acc, bits uint
+	for _, index := range indexes[1:] {
+		acc = acc<<wordBits | uint(index)
+		bits += wordBits
+		for bits >= 8 && len(data) < length {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+		acc &= 1<<bits - 1
+	}
+	// the last word is zero padded
+	if acc != 0 {
+		return nil, errors.Wrap(ErrInvalidShare, "non-zero padding")
+	}
+	return data, nil
+}
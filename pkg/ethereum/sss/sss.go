@@ -0,0 +1,153 @@
+package sss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MaxShares = 255
+	// MaxSecretSize is the longest secret whose shares Words can encode, as
+	// the first word of the encoding holds the byte length of the share.
+	MaxSecretSize = 1<<wordBits - 1 - 3 - secretChecksumLen - shareChecksumLen
+
+	secretChecksumLen = 4
+)
+
+var (
+	ErrInvalidParams    = errors.New("invalid split parameters")
+	ErrNotEnoughShares  = errors.New("not enough shares")
+	ErrIncompatible     = errors.New("shares don't belong to the same secret")
+	ErrSecretChecksum   = errors.New("recovered secret fails its checksum")
+	ErrInvalidShare     = errors.New("invalid share")
+	ErrDuplicateShare   = errors.New("duplicate share index")
+	ErrUnsupportedShare = errors.New("unsupported share version")
+)
+
+// Share is one point of the per-byte polynomials. Index is the x-coordinate and
+// is never zero, as the secret is stored at x = 0.
+type Share struct {
+	Threshold byte
+	Index     byte
+	Value     []byte
+}
+
+// Split splits secret into n shares, any threshold of which recover it. A
+// checksum of the secret is shared with it so Combine can detect wrong or
+// mixed-up shares.
+func Split(secret []byte, n, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.Wrap(ErrInvalidParams, "empty secret")
+	}
+	if len(secret) > MaxSecretSize {
+		return nil, errors.Wrapf(ErrInvalidParams, "secret longer than %d bytes", MaxSecretSize)
+	}
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, errors.Wrapf(ErrInvalidParams, "need 2 <= threshold <= n <= %d, got threshold %d of %d", MaxShares, threshold, n)
+	}
+
+	payload := withChecksum(secret)
+	defer clear(payload)
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{Threshold: byte(threshold), Index: byte(i + 1), Value: make([]byte, len(payload))}
+	}
+
+	coefficients := make([]byte, threshold)
+	defer clear(coefficients)
+	for pos, b := range payload {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			shares[i].Value[pos] = evaluate(coefficients, shares[i].Index)
+		}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from at least Threshold shares.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	threshold := int(shares[0].Threshold)
+	size := len(shares[0].Value)
+	if len(shares) < threshold {
+		return nil, errors.Wrapf(ErrNotEnoughShares, "got %d, need %d", len(shares), threshold)
+	}
+
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if int(share.Threshold) != threshold || len(share.Value) != size {
+			return nil, ErrIncompatible
+		}
+		if share.Index == 0 {
+			return nil, errors.Wrap(ErrInvalidShare, "index 0")
+		}
+		if seen[share.Index] {
+			return nil, errors.Wrapf(ErrDuplicateShare, "index %d", share.Index)
+		}
+		seen[share.Index] = true
+	}
+
+	// any threshold shares define the polynomials
+	used := shares[:threshold]
+	payload := make([]byte, size)
+	for i, share := range used {
+		basis := lagrangeAtZero(used, i)
+		for pos, y := range share.Value {
+			payload[pos] ^= gfMul(basis, y)
+		}
+	}
+
+	secret, ok := verifyChecksum(payload)
+	if !ok {
+		clear(payload)
+		return nil, ErrSecretChecksum
+	}
+	return secret, nil
+}
+
+// evaluate returns the polynomial at x with Horner's rule.
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// lagrangeAtZero returns the Lagrange basis polynomial of shares[i] at x = 0.
+func lagrangeAtZero(shares []Share, i int) byte {
+	basis := byte(1)
+	for j, share := range shares {
+		if j == i {
+			continue
+		}
+		// x_j / (x_j - x_i), subtraction is XOR
+		basis = gfMul(basis, gfDiv(share.Index, share.Index^shares[i].Index))
+	}
+	return basis
+}
+
+func withChecksum(secret []byte) []byte {
+	sum := sha256.Sum256(secret)
+	payload := make([]byte, 0, len(secret)+secretChecksumLen)
+	payload = append(payload, secret...)
+	return append(payload, sum[:secretChecksumLen]...)
+}
+
+func verifyChecksum(payload []byte) ([]byte, bool) {
+	if len(payload) <= secretChecksumLen {
+		return nil, false
+	}
+	secret := payload[:len(payload)-secretChecksumLen]
+	sum := sha256.Sum256(secret)
+	return secret, bytes.Equal(sum[:secretChecksumLen], payload[len(secret):])
+}
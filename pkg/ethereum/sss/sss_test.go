@@ -0,0 +1,144 @@
+package sss
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGF256(t *testing.T) {
+	for a := 0; a < 256; a++ {
+		for b := 0; b < 256; b++ {
+			require.Equal(t, mulNoTable(byte(a), byte(b)), gfMul(byte(a), byte(b)))
+			if b != 0 {
+				require.Equal(t, byte(a), gfDiv(gfMul(byte(a), byte(b)), byte(b)))
+			}
+		}
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+
+	shares, err := Split(secret, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	// every subset of 3 shares restores the secret
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			for k := j + 1; k < 5; k++ {
+				restored, err := Combine([]Share{shares[k], shares[i], shares[j]})
+				require.NoError(t, err)
+				require.Equal(t, secret, restored)
+			}
+		}
+	}
+	restored, err := Combine(shares)
+	require.NoError(t, err)
+	require.Equal(t, secret, restored)
+
+	_, err = Combine(shares[:2])
+	require.ErrorIs(t, err, ErrNotEnoughShares)
+	_, err = Combine([]Share{shares[0], shares[0], shares[1]})
+	require.ErrorIs(t, err, ErrDuplicateShare)
+
+	// shares of another secret fail the checksum
+	others, err := Split(secret, 5, 3)
+	require.NoError(t, err)
+	_, err = Combine([]Share{shares[0], shares[1], others[2]})
+	require.ErrorIs(t, err, ErrSecretChecksum)
+
+	_, err = Combine([]Share{shares[0], shares[1], {Threshold: 2, Index: 3, Value: shares[2].Value}})
+	require.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestSplitParams(t *testing.T) {
+	testCases := []struct {
+		secret       []byte
+		n, threshold int
+	}{
+		{nil, 3, 2},
+		{[]byte{1}, 3, 1},
+		{[]byte{1}, 2, 3},
+		{[]byte{1}, 256, 2},
+		{make([]byte, MaxSecretSize+1), 3, 2},
+	}
+	for _, tc := range testCases {
+		_, err := Split(tc.secret, tc.n, tc.threshold)
+		require.ErrorIs(t, err, ErrInvalidParams)
+	}
+
+	// the longest secret still encodes as words
+	secret := make([]byte, MaxSecretSize)
+	secret[0] = 1
+	shares, err := Split(secret, 3, 2)
+	require.NoError(t, err)
+	parsed := make([]Share, 2)
+	for i, share := range shares[:2] {
+		parsed[i], err = ParseShare(share.Words())
+		require.NoError(t, err)
+	}
+	recovered, err := Combine(parsed)
+	require.NoError(t, err)
+	require.Equal(t, secret, recovered)
+}
+
+func TestEncoding(t *testing.T) {
+	shares, err := Split([]byte("correct horse battery staple"), 3, 2)
+	require.NoError(t, err)
+
+	for _, share := range shares {
+		for _, encoded := range []string{share.Hex(), "0x" + share.Hex(), share.Words(), strings.ToUpper(share.Words())} {
+			parsed, err := ParseShare(encoded)
+			require.NoError(t, err)
+			require.Equal(t, share, parsed)
+		}
+	}
+
+	// a flipped character or a wrong word is caught by the share checksum
+	encoded := []byte(shares[0].Hex())
+	encoded[10] ^= 1
+	_, err = ParseShare(string(encoded))
+	require.ErrorIs(t, err, ErrInvalidShare)
+
+	words := strings.Fields(shares[0].Words())
+	if words[1] == "zoo" {
+		words[1] = "zebra"
+	} else {
+		words[1] = "zoo"
+	}
+	_, err = ParseShare(strings.Join(words, " "))
+	require.ErrorIs(t, err, ErrInvalidShare)
+
+	_, err = ParseShare("abandon foo")
+	require.ErrorIs(t, err, ErrInvalidShare)
+	_, err = ParseShare(strings.Join(words[:len(words)-1], " "))
+	require.ErrorIs(t, err, ErrInvalidShare)
+}
+
+// Every padding length of the last word round-trips, including those of
+// private keys and 24-word mnemonic entropy.
+func TestWordsRoundTrip(t *testing.T) {
+	for size := 1; size <= 64; size++ {
+		secret := make([]byte, size)
+		_, err := rand.Read(secret)
+		require.NoError(t, err)
+		shares, err := Split(secret, 3, 2)
+		require.NoError(t, err)
+
+		parsed := make([]Share, len(shares))
+		for i, share := range shares {
+			parsed[i], err = ParseShare(share.Words())
+			require.NoError(t, err, "%d bytes", size)
+			require.Equal(t, share, parsed[i])
+		}
+		combined, err := Combine(parsed[1:])
+		require.NoError(t, err)
+		require.Equal(t, secret, combined, "%d bytes", size)
+	}
+}
@@ -16,10 +16,7 @@ import (
 	"go.uber.org/zap"
 )
 
-const (
-	defaultGas         = 90000
-	ValidSignatureSize = 65
-)
+const ValidSignatureSize = 65
 
 type ITransactor interface {
 	NextNonce(
@@ -83,6 +80,8 @@ type Transactor struct {
 	chainId        *big.Int
 	client         *ethclient.Client
 	pendingTracker IPendingTxTracker
+	chains         *wallet_common.ChainRegistry
+	gasPolicy      GasLimitPolicy
 }
 
 func NewTransactor(
@@ -98,7 +97,35 @@ func NewTransactor(
 		chainId:        chainId,
 		client:         client,
 		pendingTracker: pendingTracker,
+		chains:         wallet_common.DefaultChainRegistry,
+		gasPolicy:      DefaultGasLimitPolicy,
+	}
+}
+
+// feePolicy returns the fee rules of chainID as configured in the chain registry.
+func (t *Transactor) feePolicy(chainID *big.Int) FeePolicy {
+	cfg, ok := t.chainConfig(chainID)
+	if !ok {
+		return DefaultFeePolicy
 	}
+	return FeePolicyForChain(cfg)
+}
+
+// SetChainRegistry overrides the registry the transactor reads chain behaviour from.
+func (t *Transactor) SetChainRegistry(chains *wallet_common.ChainRegistry) {
+	t.chains = chains
+}
+
+// chainConfig returns the registry entry of chainID, or of the transactor's own
+// chain when chainID is nil.
+func (t *Transactor) chainConfig(chainID *big.Int) (*wallet_common.ChainConfig, bool) {
+	if chainID == nil {
+		chainID = t.chainId
+	}
+	if chainID == nil || t.chains == nil {
+		return nil, false
+	}
+	return t.chains.Get(wallet_common.ChainID(chainID.Uint64()))
 }
 
 func (t *Transactor) NextNonce(ctx context.Context, chainID *big.Int, from common.Address) (uint64, error) {
@@ -107,18 +134,11 @@ func (t *Transactor) NextNonce(ctx context.Context, chainID *big.Int, from commo
 		return 0, err
 	}
 
-	var chID uint64
-	if chainID != nil {
-		chID = chainID.Uint64()
-	}
-
 	// We need to take into consideration all pending transactions in case of Optimism, cause the network returns always
 	// the nonce of last executed tx + 1 for the next nonce value.
-	if chID == wallet_common.OptimismMainnet ||
-		chID == wallet_common.OptimismSepolia ||
-		chID == wallet_common.OptimismGoerli {
+	if cfg, ok := t.chainConfig(chainID); ok && cfg.IsOptimismStack() {
 		if t.pendingTracker != nil {
-			countOfPendingTXs, err := t.pendingTracker.CountPendingTxsFromNonce(wallet_common.ChainID(chID), common.Address(from), nonce)
+			countOfPendingTXs, err := t.pendingTracker.CountPendingTxsFromNonce(cfg.ChainID, common.Address(from), nonce)
 			if err != nil {
 				return 0, err
 			}
@@ -172,7 +192,7 @@ func (t *Transactor) ValidateAndBuildTransaction(
 	sendArgs _types.SendTxArgs,
 	lastUsedNonce int64,
 ) (tx *types.Transaction, nonce uint64, err error) {
-	tx, err = t.validateAndBuildTransaction(ctx, sendArgs, lastUsedNonce)
+	tx, err = t.validateAndBuildTransaction(ctx, chainID, sendArgs, lastUsedNonce)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -180,16 +200,18 @@ func (t *Transactor) ValidateAndBuildTransaction(
 	return tx, tx.Nonce(), err
 }
 
+// AddSignatureToTransaction accepts any signature format handled by NormalizeSignature.
 func (t *Transactor) AddSignatureToTransaction(
 	chainID *big.Int,
 	tx *types.Transaction,
 	sig []byte,
 ) (*types.Transaction, error) {
-	if len(sig) != ValidSignatureSize {
-		return nil, _types.ErrInvalidSignatureSize
+	sig, err := NormalizeSignature(sig, chainID)
+	if err != nil {
+		return nil, err
 	}
 
-	signer := types.NewLondonSigner(chainID)
+	signer := types.LatestSignerForChainID(chainID)
 	txWithSignature, err := tx.WithSignature(signer, sig)
 	if err != nil {
 		return nil, err
@@ -274,12 +296,8 @@ func (t *Transactor) BuildTransactionWithSignature(
 	args _types.SendTxArgs,
 	sig []byte,
 ) (*types.Transaction, error) {
-	if !args.Valid() {
-		return nil, _types.ErrInvalidSendTxArgs
-	}
-
-	if len(sig) != ValidSignatureSize {
-		return nil, _types.ErrInvalidSignatureSize
+	if err := args.Validate(); err != nil {
+		return nil, err
 	}
 
 	tx := t.buildTransaction(args)
@@ -297,16 +315,21 @@ func (t *Transactor) BuildTransactionWithSignature(
 		return nil, err
 	}
 
+	if err := CheckSender(chainID, txWithSignature, args.From); err != nil {
+		return nil, err
+	}
+
 	return txWithSignature, nil
 }
 
 func (t *Transactor) validateAndBuildTransaction(
 	ctx context.Context,
+	chainID *big.Int,
 	args _types.SendTxArgs,
 	lastUsedNonce int64,
 ) (tx *types.Transaction, err error) {
-	if !args.Valid() {
-		return nil, _types.ErrInvalidSendTxArgs
+	if err := args.Validate(); err != nil {
+		return nil, err
 	}
 
 	var nonce uint64
@@ -324,6 +347,9 @@ func (t *Transactor) validateAndBuildTransaction(
 		}
 	}
 
+	feePolicy := t.feePolicy(chainID)
+	args, gasPriceCap := feePolicy.Normalize(args)
+
 	gasPrice := (*big.Int)(args.GasPrice)
 	// GasPrice should be estimated only for LegacyTx
 	if !args.IsDynamicFeeTx() && gasPrice == nil {
@@ -333,31 +359,31 @@ func (t *Transactor) validateAndBuildTransaction(
 		}
 	}
 
+	if args.IsDynamicFeeTx() {
+		args = feePolicy.ApplyDynamic(args)
+	} else {
+		gasPrice = feePolicy.ApplyLegacy(gasPrice, gasPriceCap)
+	}
+
 	value := (*big.Int)(args.Value)
 	var gas uint64
 	if args.Gas != nil {
 		gas = uint64(*args.Gas)
 	} else {
+		msg := ethereum.CallMsg{
+			From:  args.From,
+			To:    args.To,
+			Value: value,
+			Data:  args.GetInput(),
+		}
 		if args.IsDynamicFeeTx() {
-			gasFeeCap := (*big.Int)(args.MaxFeePerGas)
-			gasTipCap := (*big.Int)(args.MaxPriorityFeePerGas)
-			gas, err = t.client.EstimateGas(ctx, ethereum.CallMsg{
-				From:      args.From,
-				To:        args.To,
-				GasFeeCap: gasFeeCap,
-				GasTipCap: gasTipCap,
-				Value:     value,
-				Data:      args.GetInput(),
-			})
+			msg.GasFeeCap = (*big.Int)(args.MaxFeePerGas)
+			msg.GasTipCap = (*big.Int)(args.MaxPriorityFeePerGas)
 		} else {
-			gas, err = t.client.EstimateGas(ctx, ethereum.CallMsg{
-				From:     args.From,
-				To:       args.To,
-				GasPrice: gasPrice,
-				Value:    value,
-				Data:     args.GetInput(),
-			})
+			msg.GasPrice = gasPrice
 		}
+
+		gas, err = t.estimateGasLimit(ctx, args, msg)
 		if err != nil {
 			return nil, err
 		}
@@ -374,23 +400,16 @@ func (t *Transactor) validateAndPropagate(
 	args _types.SendTxArgs,
 	lastUsedNonce int64,
 ) (hash _types.Hash, nonce uint64, err error) {
-	tx, err := t.validateAndBuildTransaction(ctx, args, lastUsedNonce)
-	if err != nil {
-		return hash, nonce, err
+	if signer.Address() != args.From {
+		return hash, nonce, _types.ErrInvalidTxSender
 	}
 
-	// 计算hash
-	eSigner := types.NewLondonSigner(chainID)
-
-	chash := eSigner.Hash(tx)
-
-	// sign
-	sig, err := signer.Sign(chash.Bytes())
+	tx, err := t.validateAndBuildTransaction(ctx, chainID, args, lastUsedNonce)
 	if err != nil {
 		return hash, nonce, err
 	}
 
-	signedTx, err := tx.WithSignature(eSigner, sig)
+	signedTx, err := signer.SignTx(chainID, tx)
 	if err != nil {
 		return hash, nonce, err
 	}
@@ -21,7 +21,7 @@ var (
 )
 
 var (
-	testGas      = hexutil.Uint64(defaultGas + 1)
+	testGas      = hexutil.Uint64(ERC20TransferGas + 1)
 	testGasPrice = (*hexutil.Big)(big.NewInt(10))
 	testNonce    = hexutil.Uint64(10)
 )
@@ -109,7 +109,7 @@ func (s *TransactorSuite) TestGasValues() {
 			to := common.HexToAddress(Address2)
 
 			args := _types.SendTxArgs{
-				From:                 common.HexToAddress(Address1),
+				From:                 signer.Address(),
 				To:                   &to,
 				Gas:                  testCase.gas,
 				GasPrice:             testCase.gasPrice,
@@ -1,7 +1,6 @@
 package types
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -22,8 +21,11 @@ var (
 	//ErrAccountDoesntExist is sent when provided sub-account is not stored in database.
 	ErrAccountDoesntExist = errors.New("account doesn't exist")
 
-	// ErrInvalidSignatureSize is returned if a signature is not 65 bytes to avoid panic from go-ethereum
-	ErrInvalidSignatureSize = errors.New("signature size must be 65")
+	// ErrInvalidSignatureSize is returned if a signature is shorter than an EIP-2098 compact one (64 bytes)
+	// or has a V longer than 32 bytes (96 bytes in total), to avoid panic from go-ethereum
+	ErrInvalidSignatureSize = errors.New("signature size must be between 64 and 96 bytes")
+	// ErrInvalidSignature is returned when a signature has out of range values or can't be recovered.
+	ErrInvalidSignature = errors.New("invalid signature")
 )
 
 type ErrBadNonce struct {
@@ -35,6 +37,21 @@ func (e *ErrBadNonce) Error() string {
 	return fmt.Sprintf("bad nonce. expected %d, got %d", e.ExpectedNonce, e.Nonce)
 }
 
+// ErrSenderMismatch is returned when a signature recovers to another account
+// than the From of the transaction. It matches ErrInvalidTxSender with errors.Is.
+type ErrSenderMismatch struct {
+	Expected  common.Address
+	Recovered common.Address
+}
+
+func (e *ErrSenderMismatch) Error() string {
+	return fmt.Sprintf("signature is from %s, expected %s", e.Recovered.Hex(), e.Expected.Hex())
+}
+
+func (e *ErrSenderMismatch) Unwrap() error {
+	return ErrInvalidTxSender
+}
+
 type SendTxArgs struct {
 	From                 common.Address  `json:"from"`
 	To                   *common.Address `json:"to"`
@@ -65,12 +82,9 @@ func (args SendTxArgs) GetInput() []byte {
 	return args.Data
 }
 
+// Valid reports whether Validate finds no error.
 func (args SendTxArgs) Valid() bool {
-	if len(args.Input) == 0 || len(args.Data) == 0 {
-		return true
-	}
-
-	return bytes.Equal(args.Input, args.Data)
+	return args.Validate() == nil
 }
 
 func (args SendTxArgs) ToTransactOpts(signerFn bind.SignerFn) *bind.TransactOpts {
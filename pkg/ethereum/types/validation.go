@@ -0,0 +1,133 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// FieldError describes why a single SendTxArgs field is invalid. Field is the
+// field's JSON name so API layers can point the user at it.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Unwrap makes errors.Is(err, ErrInvalidSendTxArgs) hold for every field error.
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidSendTxArgs
+}
+
+// ValidationErrors collects all field errors of a SendTxArgs.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fieldErr := range e {
+		msgs[i] = fieldErr.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// Fields returns the names of the offending fields.
+func (e ValidationErrors) Fields() []string {
+	fields := make([]string, len(e))
+	for i, fieldErr := range e {
+		fields[i] = fieldErr.Field
+	}
+	return fields
+}
+
+// precompiles are the precompiled contracts of mainnet (0x01-0x11) and the
+// RIP-7212 P256VERIFY precompile deployed on several L2s. None of them can
+// do anything with value sent to them.
+var precompiles = func() map[common.Address]bool {
+	m := make(map[common.Address]bool)
+	for i := int64(0x01); i <= 0x11; i++ {
+		m[common.BigToAddress(big.NewInt(i))] = true
+	}
+	m[common.BigToAddress(big.NewInt(0x100))] = true
+	return m
+}()
+
+func IsPrecompile(address common.Address) bool {
+	return precompiles[address]
+}
+
+func isNegative(v *hexutil.Big) bool {
+	return v != nil && v.ToInt().Sign() < 0
+}
+
+// Validate checks the consistency of the arguments and returns ValidationErrors
+// naming every offending field, or nil.
+func (args SendTxArgs) Validate() error {
+	var errs ValidationErrors
+	add := func(field string, reason string) {
+		errs = append(errs, &FieldError{Field: field, Reason: reason})
+	}
+
+	if len(args.Input) > 0 && len(args.Data) > 0 && !bytes.Equal(args.Input, args.Data) {
+		add("input", "input and data are both set and differ")
+	}
+
+	for _, f := range []struct {
+		name  string
+		value *hexutil.Big
+	}{
+		{"value", args.Value},
+		{"gasPrice", args.GasPrice},
+		{"maxFeePerGas", args.MaxFeePerGas},
+		{"maxPriorityFeePerGas", args.MaxPriorityFeePerGas},
+	} {
+		if isNegative(f.value) {
+			add(f.name, "must not be negative")
+		}
+	}
+
+	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
+		add("gasPrice", "cannot be combined with maxFeePerGas or maxPriorityFeePerGas")
+	}
+
+	switch {
+	case args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas == nil:
+		add("maxPriorityFeePerGas", "required when maxFeePerGas is set")
+	case args.MaxFeePerGas == nil && args.MaxPriorityFeePerGas != nil:
+		add("maxFeePerGas", "required when maxPriorityFeePerGas is set")
+	case args.IsDynamicFeeTx() && args.MaxPriorityFeePerGas.ToInt().Cmp(args.MaxFeePerGas.ToInt()) > 0:
+		add("maxPriorityFeePerGas", "must not exceed maxFeePerGas")
+	}
+
+	if args.Gas != nil && uint64(*args.Gas) < params.TxGas {
+		add("gas", fmt.Sprintf("must be at least the intrinsic gas of %d", params.TxGas))
+	}
+
+	if args.To == nil {
+		if len(args.GetInput()) == 0 {
+			add("data", "contract creation requires data")
+		}
+	} else if IsPrecompile(*args.To) && args.Value != nil && args.Value.ToInt().Sign() > 0 {
+		add("value", fmt.Sprintf("precompile %s is not payable", args.To.Hex()))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendTxArgsValidate(t *testing.T) {
+	to := common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+	ecrecover := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	hexBig := func(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+	gas := func(v uint64) *hexutil.Uint64 { return (*hexutil.Uint64)(&v) }
+
+	testCases := []struct {
+		name   string
+		args   SendTxArgs
+		fields []string
+	}{
+		{"transfer", SendTxArgs{To: &to, Value: hexBig(1)}, nil},
+		{"dynamic", SendTxArgs{To: &to, MaxFeePerGas: hexBig(2), MaxPriorityFeePerGas: hexBig(1)}, nil},
+		{"deploy", SendTxArgs{Data: []byte{0x60}}, nil},
+		{"inputDataMismatch", SendTxArgs{To: &to, Input: []byte{1}, Data: []byte{2}}, []string{"input"}},
+		{"gasPriceAndMaxFee", SendTxArgs{To: &to, GasPrice: hexBig(1), MaxFeePerGas: hexBig(2), MaxPriorityFeePerGas: hexBig(1)}, []string{"gasPrice"}},
+		{"maxFeeOnly", SendTxArgs{To: &to, MaxFeePerGas: hexBig(2)}, []string{"maxPriorityFeePerGas"}},
+		{"priorityOnly", SendTxArgs{To: &to, MaxPriorityFeePerGas: hexBig(2)}, []string{"maxFeePerGas"}},
+		{"priorityAboveCap", SendTxArgs{To: &to, MaxFeePerGas: hexBig(1), MaxPriorityFeePerGas: hexBig(2)}, []string{"maxPriorityFeePerGas"}},
+		{"negative", SendTxArgs{To: &to, Value: hexBig(-1), GasPrice: hexBig(-1)}, []string{"value", "gasPrice"}},
+		{"emptyCreation", SendTxArgs{}, []string{"data"}},
+		{"lowGas", SendTxArgs{To: &to, Gas: gas(20000)}, []string{"gas"}},
+		{"payPrecompile", SendTxArgs{To: &ecrecover, Value: hexBig(1)}, []string{"value"}},
+		{"callPrecompile", SendTxArgs{To: &ecrecover, Data: []byte{1}}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.args.Validate()
+			if tc.fields == nil {
+				require.NoError(t, err)
+				require.True(t, tc.args.Valid())
+				return
+			}
+
+			var validationErrs ValidationErrors
+			require.True(t, errors.As(err, &validationErrs))
+			require.Equal(t, tc.fields, validationErrs.Fields())
+			require.ErrorIs(t, err, ErrInvalidSendTxArgs)
+
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr))
+			require.Equal(t, tc.fields[0], fieldErr.Field)
+		})
+	}
+}